@@ -0,0 +1,25 @@
+// In this example, we run the database migrations of a Drupal installation
+// whenever the app version is changed.
+
+action "mittwald_app_exec" "drush_updb" {
+  config {
+    app_id  = mittwald_app.drupal.id
+    command = "vendor/bin/drush updb -y"
+    timeout = "5m"
+
+    environment = {
+      DRUSH_OPTIONS_URI = "https://example.com"
+    }
+  }
+}
+
+resource "terraform_data" "drupal_version" {
+  input = mittwald_app.drupal.version
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.mittwald_app_exec.drush_updb]
+    }
+  }
+}
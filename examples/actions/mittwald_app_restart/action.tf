@@ -0,0 +1,22 @@
+// In this example, we define an action to restart a Node.js app whenever its
+// configuration file is updated.
+
+action "mittwald_app_restart" "node" {
+  config {
+    app_id = mittwald_app.node.id
+  }
+}
+
+resource "mittwald_remote_file" "node_config" {
+  app_id = mittwald_app.node.id
+
+  path     = "${mittwald_app.node.installation_path_absolute}/config.json"
+  contents = jsonencode({ greeting = "Hello World" })
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.mittwald_app_restart.node]
+    }
+  }
+}
@@ -0,0 +1,9 @@
+// In this example, we define an action to start a previously stopped Node.js
+// app. It can be invoked on demand using
+// `terraform apply -invoke=action.mittwald_app_start.node`.
+
+action "mittwald_app_start" "node" {
+  config {
+    app_id = mittwald_app.node.id
+  }
+}
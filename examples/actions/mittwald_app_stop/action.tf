@@ -0,0 +1,9 @@
+// In this example, we define an action to stop a Node.js app. It can be
+// invoked on demand using
+// `terraform apply -invoke=action.mittwald_app_stop.node`.
+
+action "mittwald_app_stop" "node" {
+  config {
+    app_id = mittwald_app.node.id
+  }
+}
@@ -0,0 +1,10 @@
+// In this example, we define an action to upgrade a WordPress installation to
+// the latest 6.x version. It can be invoked on demand using
+// `terraform apply -invoke=action.mittwald_app_upgrade.wordpress`.
+
+action "mittwald_app_upgrade" "wordpress" {
+  config {
+    app_id  = mittwald_app.wordpress.id
+    version = "~6"
+  }
+}
@@ -0,0 +1,22 @@
+// In this example, we run the database migrations of a Laravel application
+// whenever the container stack is updated.
+
+action "mittwald_container_exec" "migrate" {
+  config {
+    stack_id     = mittwald_container_stack.app.id
+    container_id = mittwald_container_stack.app.containers.app.id
+    command      = ["php", "artisan", "migrate", "--force"]
+    timeout      = "5m"
+  }
+}
+
+resource "terraform_data" "app_version" {
+  input = mittwald_container_stack.app.containers.app.image
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.mittwald_container_exec.migrate]
+    }
+  }
+}
@@ -0,0 +1,9 @@
+// In this example, we define an action to abort all running executions of a
+// long-running import cronjob. It can be invoked on demand using
+// `terraform apply -invoke=action.mittwald_cronjob_abort.import`.
+
+action "mittwald_cronjob_abort" "import" {
+  config {
+    cronjob_id = mittwald_cronjob.import.id
+  }
+}
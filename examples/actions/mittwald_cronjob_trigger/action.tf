@@ -0,0 +1,37 @@
+// In this example, we warm up the cache of an app right after each deployment,
+// using a cronjob that otherwise runs once a day.
+
+resource "mittwald_cronjob" "cache_warmup" {
+  project_id  = mittwald_project.example.id
+  app_id      = mittwald_app.example.id
+  description = "Warm up cache"
+  interval    = "0 3 * * *"
+
+  destination = {
+    command = {
+      interpreter = "/usr/bin/php"
+      path        = "/html/bin/warmup.php"
+    }
+  }
+}
+
+action "mittwald_cronjob_trigger" "cache_warmup" {
+  config {
+    cronjob_id = mittwald_cronjob.cache_warmup.id
+    wait       = true
+    timeout    = "15m"
+  }
+}
+
+resource "mittwald_remote_directory" "release" {
+  app_id = mittwald_app.example.id
+  path   = mittwald_app.example.installation_path_absolute
+  source = "${path.module}/build"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.mittwald_cronjob_trigger.cache_warmup]
+    }
+  }
+}
@@ -0,0 +1,38 @@
+// In this example, we define an action to dump the production database
+// before the database resource is changed. The dump is written to
+// "backups/production.sql.gz", and its checksum to
+// "backups/production.sql.gz.sha256".
+
+action "mittwald_mysql_dump" "backup" {
+  config {
+    database_id = mittwald_mysql_database.production.id
+    destination = "${path.module}/backups/production.sql.gz"
+
+    user     = mittwald_mysql_database.production.user.name
+    password = var.database_password
+  }
+}
+
+resource "mittwald_mysql_database" "production" {
+  project_id  = mittwald_project.foobar.id
+  version     = "8.0"
+  description = "Production"
+
+  character_settings = {
+    character_set = "utf8mb4"
+    collation     = "utf8mb4_general_ci"
+  }
+
+  user = {
+    access_level    = "full"
+    password        = var.database_password
+    external_access = false
+  }
+
+  lifecycle {
+    action_trigger {
+      events  = [before_update]
+      actions = [action.mittwald_mysql_dump.backup]
+    }
+  }
+}
@@ -0,0 +1,13 @@
+// In this example, we define an action to seed a staging database from a
+// sanitized dump. It can be invoked on demand using
+// `terraform apply -invoke=action.mittwald_mysql_import.seed_staging`.
+
+action "mittwald_mysql_import" "seed_staging" {
+  config {
+    database_id = mittwald_mysql_database.staging.id
+    source      = "${path.module}/dumps/staging.sql.gz"
+
+    user     = mittwald_mysql_database.staging.user.name
+    password = var.database_password
+  }
+}
@@ -0,0 +1,23 @@
+// In this example, we look up all WordPress installations in a project that
+// is managed by another team, and configure a cronjob for each of them.
+
+data "mittwald_apps" "wordpress" {
+  project_id = var.project_id
+  app        = "wordpress"
+}
+
+resource "mittwald_cronjob" "wp_cron" {
+  for_each = { for app in data.mittwald_apps.wordpress.apps : app.id => app }
+
+  project_id  = var.project_id
+  app_id      = each.value.id
+  description = "WP-Cron for ${each.value.description}"
+  interval    = "*/15 * * * *"
+
+  destination = {
+    command = {
+      interpreter = "/usr/bin/php"
+      path        = "${each.value.installation_path}/wp-cron.php"
+    }
+  }
+}
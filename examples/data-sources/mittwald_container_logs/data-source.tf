@@ -0,0 +1,9 @@
+data "mittwald_container_logs" "nginx" {
+  stack_id     = mittwald_container_stack.nginx.id
+  container_id = mittwald_container_stack.nginx.containers.nginx.id
+  tail         = 50
+}
+
+output "nginx_logs" {
+  value = data.mittwald_container_logs.nginx.logs
+}
@@ -0,0 +1,15 @@
+data "mittwald_cronjob_executions" "backup" {
+  cronjob_id = mittwald_cronjob.backup.id
+  limit      = 3
+  log_lines  = 20
+}
+
+check "backup_cronjob" {
+  assert {
+    condition = alltrue([
+      for execution in data.mittwald_cronjob_executions.backup.executions :
+      execution.successful || execution.end == null
+    ])
+    error_message = "The backup cronjob has been failing: ${join("\n", [for e in data.mittwald_cronjob_executions.backup.executions : coalesce(e.log, e.status)])}"
+  }
+}
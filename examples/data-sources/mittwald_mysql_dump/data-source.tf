@@ -0,0 +1,15 @@
+// In this example, the dump written by the `mittwald_mysql_dump` action is
+// verified against its checksum file, and its checksum is used to detect
+// changes to the uploaded backup.
+
+data "mittwald_mysql_dump" "backup" {
+  path = "${path.module}/backups/production.sql.gz"
+}
+
+resource "aws_s3_object" "backup" {
+  bucket       = "my-backups"
+  key          = "production/${data.mittwald_mysql_dump.backup.sha256}.sql.gz"
+  source       = data.mittwald_mysql_dump.backup.path
+  source_hash  = data.mittwald_mysql_dump.backup.sha256
+  content_type = "application/gzip"
+}
@@ -0,0 +1,21 @@
+data "mittwald_mysql_versions" "mysql8" {
+  project_id = mittwald_project.foobar.id
+  selector   = "~8.0"
+}
+
+resource "mittwald_mysql_database" "foobar_database" {
+  project_id  = mittwald_project.foobar.id
+  version     = data.mittwald_mysql_versions.mysql8.version
+  description = "Foo"
+
+  character_settings = {
+    character_set = "utf8mb4"
+    collation     = "utf8mb4_general_ci"
+  }
+
+  user = {
+    access_level    = "full"
+    password        = var.database_password
+    external_access = false
+  }
+}
@@ -0,0 +1,10 @@
+data "mittwald_redis_versions" "recommended" {
+  project_id = mittwald_project.foobar.id
+  selector   = "recommended"
+}
+
+resource "mittwald_redis_database" "foobar_database" {
+  project_id  = mittwald_project.foobar.id
+  version     = data.mittwald_redis_versions.recommended.version
+  description = "Foo"
+}
@@ -0,0 +1,8 @@
+data "mittwald_remote_file" "wp_config" {
+  app_id = mittwald_app.wordpress.id
+  path   = "${mittwald_app.wordpress.installation_path_absolute}/wp-config.php"
+}
+
+output "wp_config_sha256" {
+  value = data.mittwald_remote_file.wp_config.sha256
+}
@@ -0,0 +1,47 @@
+/*
+This example deploys a custom Node.js app with a custom start command.
+
+The API token is defined as a sensitive input variable and passed to the
+app as a write-only environment variable; increment
+`sensitive_environment_version` to apply a changed token.
+*/
+
+variable "api_token" {
+  description = "The API token for the node app"
+  type        = string
+  sensitive   = true
+}
+
+data "mittwald_systemsoftware" "node" {
+  name     = "node"
+  selector = "^22"
+}
+
+resource "mittwald_app" "node" {
+  project_id = mittwald_project.foobar.id
+
+  app     = "node"
+  version = "1.0.0"
+
+  description   = "Martins Node-App"
+  update_policy = "none"
+
+  start_command = "npm start"
+
+  environment = {
+    "NODE_ENV" = "production"
+    "PORT"     = "3000"
+  }
+
+  sensitive_environment = {
+    "API_TOKEN" = var.api_token
+  }
+  sensitive_environment_version = 1
+
+  dependencies = {
+    (data.mittwald_systemsoftware.node.name) = {
+      version       = data.mittwald_systemsoftware.node.version
+      update_policy = "patchLevel"
+    }
+  }
+}
@@ -31,7 +31,10 @@ resource "mittwald_app" "wordpress" {
   user_inputs = {
     "site_title"  = "My awesome site"
     "admin_user"  = "martin"
-    "admin_pass"  = var.admin_password
     "admin_email" = "martin@mittwald.example"
   }
+
+  sensitive_user_inputs = {
+    "admin_pass" = var.admin_password
+  }
 }
\ No newline at end of file
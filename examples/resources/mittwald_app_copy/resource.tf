@@ -0,0 +1,14 @@
+resource "mittwald_project" "staging" {
+  server_id   = var.server_id
+  description = "Staging"
+}
+
+resource "mittwald_app_copy" "staging" {
+  source_app_id = mittwald_app.wordpress.id
+  project_id    = mittwald_project.staging.id
+  description   = "WordPress (staging)"
+}
+
+output "staging_path" {
+  value = mittwald_app_copy.staging.installation_path_absolute
+}
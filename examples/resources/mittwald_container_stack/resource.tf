@@ -21,6 +21,13 @@ resource "mittwald_container_stack" "nginx" {
       //   FOO = "bar"
       // }
 
+      // Secret environment variables are never stored in the state; increment
+      // the version to apply changed values.
+      // secret_environment = {
+      //   API_KEY = var.api_key
+      // }
+      // secret_environment_version = 1
+
       ports = [
         {
           container_port = 80
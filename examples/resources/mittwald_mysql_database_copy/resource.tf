@@ -0,0 +1,23 @@
+ephemeral "mittwald_mysql_password" "preview" {
+  length = 24
+}
+
+resource "mittwald_mysql_database_copy" "preview" {
+  source_database_id = mittwald_mysql_database.production.id
+  description        = "Preview database"
+
+  character_settings = {
+    character_set = "utf8mb4"
+    collation     = "utf8mb4_unicode_ci"
+  }
+
+  user = {
+    password_wo         = ephemeral.mittwald_mysql_password.preview.password
+    password_wo_version = 1
+    access_level        = "full"
+  }
+}
+
+output "preview_database" {
+  value = "${mittwald_mysql_database_copy.preview.name}@${mittwald_mysql_database_copy.preview.hostname}"
+}
@@ -1,3 +1,9 @@
+variable "redis_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
 resource "mittwald_redis_database" "foobar_database" {
   project_id  = mittwald_project.foobar.id
   version     = "7.2"
@@ -8,4 +14,14 @@ resource "mittwald_redis_database" "foobar_database" {
     max_memory_policy = "allkeys-lru"
     persistent        = true
   }
+
+  users = {
+    app = {
+      password_wo         = var.redis_password
+      password_wo_version = 1
+      keys                = ["app:*"]
+      channels            = ["app:*"]
+      commands            = ["+@all", "-@dangerous"]
+    }
+  }
 }
@@ -0,0 +1,14 @@
+resource "mittwald_remote_directory" "theme" {
+  app_id = mittwald_app.wordpress.id # either container_id+stack_id or app_id
+
+  path   = "${mittwald_app.wordpress.installation_path_absolute}/wp-content/themes/custom"
+  source = "${path.module}/theme"
+
+  # Optional: additional files, or files overriding files from the source directory
+  files = {
+    "config.json" = jsonencode({ environment = "production" })
+  }
+
+  # Optional: delete remote files that do not exist locally
+  delete_unmanaged = true
+}
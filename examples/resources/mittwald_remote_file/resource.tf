@@ -15,4 +15,13 @@ resource "mittwald_remote_file" "example" {
 
   # Alternatively, use the contents_from_url attribute to fetch content from a URL
   # contents_from_url = "https://example.com/file.txt"
+
+  # Alternatively, upload a local (possibly binary) file, or specify base64-encoded contents
+  # source         = "${path.module}/logo.png"
+  # content_base64 = filebase64("${path.module}/logo.png")
+
+  # Optional: file mode and ownership (numeric IDs)
+  # mode  = "0640"
+  # owner = 1000
+  # group = 1000
 }
@@ -3,6 +3,7 @@ package apiext
 import (
 	"context"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/appclientv2"
 	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
 	"github.com/mittwald/terraform-provider-mittwald/internal/apiutils"
+	"github.com/mittwald/terraform-provider-mittwald/internal/shellutil"
 )
 
 type AppClient interface {
@@ -19,9 +21,12 @@ type AppClient interface {
 	GetSystemsoftwareAndVersion(ctx context.Context, systemSoftwareID, systemSoftwareVersionID string) (*appv2.SystemSoftware, *appv2.SystemSoftwareVersion, error)
 	SelectSystemsoftwareVersion(ctx context.Context, systemSoftwareID, versionSelector string) (SystemSoftwareVersionSet, error)
 	UpdateAppinstallation(ctx context.Context, appInstallationID string, updater ...AppInstallationUpdater) error
+	RunAppInstallationAction(ctx context.Context, appInstallationID string, action appv2.Action) error
 	WaitUntilAppInstallationIsReady(ctx context.Context, appID string) error
+	WaitUntilAppInstallationStatus(ctx context.Context, appInstallationID string, check func(status *appv2.AppInstallationStatus) bool) error
 	GetAppByName(ctx context.Context, name string) (*appv2.App, bool, error)
 	SelectAppVersion(ctx context.Context, appID, versionSelector string) (AppVersionSet, error)
+	ListAppUpdateCandidates(ctx context.Context, appID, baseAppVersionID string) (AppVersionSet, error)
 }
 
 type appClient struct {
@@ -65,6 +70,53 @@ func UpdateAppInstallationUpdatePolicy(updatePolicy appv2.AppUpdatePolicy) AppIn
 	})
 }
 
+func UpdateAppInstallationVersion(appVersionID string) AppInstallationUpdater {
+	return AppInstallationUpdaterFunc(func(b *appclientv2.PatchAppinstallationRequestBody) {
+		b.AppVersionId = &appVersionID
+	})
+}
+
+// UpdateAppInstallationUserInputs sets the given user inputs. The API replaces
+// the user inputs of an installation as a whole (inputs that are missing from
+// the request are removed), so the request contains the full set: the current
+// user inputs of the installation, overridden by userInputs.
+func UpdateAppInstallationUserInputs(current []appv2.SavedUserInput, userInputs map[string]string) AppInstallationUpdater {
+	return AppInstallationUpdaterFunc(func(b *appclientv2.PatchAppinstallationRequestBody) {
+		merged := make(map[string]string, len(current)+len(userInputs))
+		for _, input := range current {
+			merged[input.Name] = input.Value
+		}
+
+		for name, value := range userInputs {
+			merged[name] = value
+		}
+
+		names := make([]string, 0, len(merged))
+		for name := range merged {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		b.UserInputs = make([]appv2.SavedUserInput, 0, len(names))
+		for _, name := range names {
+			b.UserInputs = append(b.UserInputs, appv2.SavedUserInput{Name: name, Value: merged[name]})
+		}
+	})
+}
+
+// StartCommandUserInput is the user input in which custom apps (like Node.js
+// or Python apps) store the command that is used to start the app.
+const StartCommandUserInput = "entrypoint"
+
+// BuildStartCommand builds the start command of a custom app from the actual
+// command and the environment variables that should be set for it. The API has
+// no separate setting for environment variables, so these are exported by the
+// start command itself before the actual command is run.
+func BuildStartCommand(command string, env map[string]string) (string, error) {
+	return shellutil.BuildShellCommand("", env, command)
+}
+
 func UpdateAppInstallationDescription(description string) AppInstallationUpdater {
 	return AppInstallationUpdaterFunc(func(b *appclientv2.PatchAppinstallationRequestBody) {
 		b.Description = &description
@@ -116,6 +168,18 @@ func (c *appClient) UpdateAppinstallation(ctx context.Context, appInstallationID
 	return err
 }
 
+// RunAppInstallationAction runs an action (like a restart) on the given app
+// installation; see retryWhilePhaseNotReady.
+func (c *appClient) RunAppInstallationAction(ctx context.Context, appInstallationID string, action appv2.Action) error {
+	_, err := c.retryWhilePhaseNotReady(ctx, func(ctx context.Context) (*http.Response, error) {
+		return c.ExecuteAction(ctx, appclientv2.ExecuteActionRequest{
+			AppInstallationID: appInstallationID,
+			Action:            action,
+		})
+	})
+	return err
+}
+
 // LinkDatabase wraps the generated client call with a retry on the transient
 // "not in ready phase" error; see retryWhilePhaseNotReady.
 func (c *appClient) LinkDatabase(ctx context.Context, req appclientv2.LinkDatabaseRequest, reqEditors ...func(req *http.Request) error) (*http.Response, error) {
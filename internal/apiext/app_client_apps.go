@@ -96,3 +96,18 @@ func (c *appClient) SelectAppVersion(ctx context.Context, appID, versionSelector
 
 	return set, nil
 }
+
+// ListAppUpdateCandidates lists the versions that an installation of the given
+// app version can be upgraded to, sorted in ascending order.
+func (c *appClient) ListAppUpdateCandidates(ctx context.Context, appID, baseAppVersionID string) (AppVersionSet, error) {
+	candidatesRequest := appclientv2.ListUpdateCandidatesForAppversionRequest{AppID: appID, BaseAppVersionID: baseAppVersionID}
+	candidates, _, err := c.ListUpdateCandidatesForAppversion(ctx, candidatesRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	set := AppVersionSet(*candidates)
+	sort.Sort(set)
+
+	return set, nil
+}
@@ -0,0 +1,57 @@
+package apiext
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/appclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiutils"
+)
+
+// WaitUntilAppInstallationStatus waits until the runtime status of the given
+// app installation (as opposed to its phase, see
+// WaitUntilAppInstallationIsReady) satisfies the given check. This is used to
+// make sure that a lifecycle action like a restart has actually taken effect.
+func (c *appClient) WaitUntilAppInstallationStatus(ctx context.Context, appInstallationID string, check func(status *appv2.AppInstallationStatus) bool) error {
+	request := appclientv2.RetrieveStatusRequest{AppInstallationID: appInstallationID}
+
+	runner := func(ctx context.Context, req appclientv2.RetrieveStatusRequest, reqEditors ...func(req *http.Request) error) (*appv2.AppInstallationStatus, *http.Response, error) {
+		status, resp, err := c.RetrieveStatus(ctx, req, reqEditors...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !check(status) {
+			tflog.Debug(ctx, "app installation status not reached yet", map[string]any{
+				"app_installation_id": appInstallationID,
+				"state":               string(status.State),
+			})
+			return nil, nil, apiutils.ErrPollShouldRetry
+		}
+
+		return status, resp, nil
+	}
+
+	o := apiutils.PollOpts{
+		InitialDelay: 1 * time.Second,
+		MaxDelay:     10 * time.Second,
+	}
+
+	_, err := apiutils.PollRequest(ctx, o, runner, request)
+	return err
+}
+
+// AppInstallationHasRestartedSince reports whether the app is running and has
+// been started after the restart was requested. Checking the state alone is
+// not enough, since the app may still be running the old process.
+func AppInstallationHasRestartedSince(status *appv2.AppInstallationStatus, requested time.Time) bool {
+	if status.State != appv2.AppInstallationStatusStateRunning || status.UptimeSeconds == nil {
+		return false
+	}
+
+	uptime := time.Duration(*status.UptimeSeconds * float64(time.Second))
+	return uptime <= time.Since(requested)
+}
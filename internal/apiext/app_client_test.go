@@ -0,0 +1,34 @@
+package apiext
+
+import (
+	"testing"
+
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/appclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
+	. "github.com/onsi/gomega"
+)
+
+func TestUpdateAppInstallationUserInputs(t *testing.T) {
+	g := NewWithT(t)
+
+	current := []appv2.SavedUserInput{
+		{Name: "site_title", Value: "My Site"},
+		{Name: "admin_password", Value: "secret"},
+		{Name: StartCommandUserInput, Value: "node old.js"},
+	}
+
+	body := appclientv2.PatchAppinstallationRequestBody{}
+	AppInstallationUpdaterChain{
+		UpdateAppInstallationDescription("staging"),
+		UpdateAppInstallationUserInputs(current, map[string]string{
+			"site_title":          "Staging",
+			StartCommandUserInput: "node server.js",
+		}),
+	}.Apply(&body)
+
+	g.Expect(body.UserInputs).To(Equal([]appv2.SavedUserInput{
+		{Name: "admin_password", Value: "secret"},
+		{Name: StartCommandUserInput, Value: "node server.js"},
+		{Name: "site_title", Value: "Staging"},
+	}))
+}
@@ -18,6 +18,7 @@ type ContainerClient interface {
 	PollDefaultStack(context.Context, string) (*containerv2.StackResponse, error)
 	GetRegistryByName(ctx context.Context, projectID string, registryURI string) (*containerv2.Registry, error)
 	WaitUntilStackIsReady(ctx context.Context, stackID string, containerNames []string) error
+	GetServiceLogTail(ctx context.Context, stackID, serviceID string, lines int64) (string, error)
 }
 type containerClient struct {
 	containerclientv2.Client
@@ -0,0 +1,54 @@
+package apiext
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/containerclientv2"
+)
+
+// DefaultServiceLogTail is the number of log lines that are attached to
+// diagnostics about failing containers.
+const DefaultServiceLogTail int64 = 50
+
+// GetServiceLogTail returns (at most) the last `lines` lines of the logs of the
+// given service.
+func (c *containerClient) GetServiceLogTail(ctx context.Context, stackID, serviceID string, lines int64) (string, error) {
+	request := containerclientv2.GetServiceLogsRequest{
+		StackID:   stackID,
+		ServiceID: serviceID,
+		Tail:      &lines,
+	}
+
+	logs, _, err := c.GetServiceLogs(ctx, request)
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs of service %s: %w", serviceID, err)
+	}
+
+	if logs == nil {
+		return "", nil
+	}
+
+	// The API should already honor the tail parameter; trim the result anyway,
+	// so that a misbehaving (or ignored) parameter does not flood the user's
+	// terminal with diagnostics.
+	return tailLines(*logs, lines), nil
+}
+
+// tailLines returns the last n lines of s. A trailing newline is not counted as
+// an additional (empty) line.
+func tailLines(s string, n int64) string {
+	if n <= 0 {
+		return ""
+	}
+
+	s = strings.TrimRight(s, "\n")
+	lines := strings.Split(s, "\n")
+
+	if int64(len(lines)) <= n {
+		return s
+	}
+
+	return strings.Join(lines[int64(len(lines))-n:], "\n")
+}
@@ -0,0 +1,54 @@
+package apiext
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTailLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		n      int64
+		expect string
+	}{
+		{
+			name:   "fewer lines than requested",
+			input:  "a\nb\n",
+			n:      5,
+			expect: "a\nb",
+		},
+		{
+			name:   "exactly as many lines as requested",
+			input:  "a\nb\nc",
+			n:      3,
+			expect: "a\nb\nc",
+		},
+		{
+			name:   "more lines than requested",
+			input:  "a\nb\nc\nd\n",
+			n:      2,
+			expect: "c\nd",
+		},
+		{
+			name:   "empty input",
+			input:  "",
+			n:      3,
+			expect: "",
+		},
+		{
+			name:   "zero lines requested",
+			input:  "a\nb",
+			n:      0,
+			expect: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tailLines(tt.input, tt.n)).To(Equal(tt.expect))
+		})
+	}
+}
@@ -2,7 +2,6 @@ package apiext
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"time"
 
@@ -14,6 +13,9 @@ import (
 // WaitUntilStackIsReady waits until the specified stack is ready, meaning all
 // specified containers are running. If `containerNames` is nil, it waits for all
 // containers in the stack to be running.
+//
+// If one of the awaited containers enters the error state, an
+// *ErrServiceInErrorState is returned.
 func (c *containerClient) WaitUntilStackIsReady(ctx context.Context, stackID string, containerNames []string) error {
 	containerNameMap := make(map[string]struct{}, len(containerNames))
 	for _, name := range containerNames {
@@ -36,7 +38,11 @@ func (c *containerClient) WaitUntilStackIsReady(ctx context.Context, stackID str
 			}
 
 			if service.Status == containerv2.ServiceStatusError {
-				return nil, nil, fmt.Errorf("stack has service '%s' in error state", service.ServiceName)
+				return nil, nil, &ErrServiceInErrorState{
+					StackID:     stackID,
+					ServiceID:   service.Id,
+					ServiceName: service.ServiceName,
+				}
 			}
 
 			if service.Status != containerv2.ServiceStatusRunning {
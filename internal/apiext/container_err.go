@@ -7,3 +7,16 @@ type ErrNoDefaultStack struct {
 func (e *ErrNoDefaultStack) Error() string {
 	return "project " + e.ProjectID + " does not have a default stack"
 }
+
+// ErrServiceInErrorState is returned by WaitUntilStackIsReady when one of the
+// awaited services has entered the error state. It carries enough information
+// for callers to look up the failing service (for example, to fetch its logs).
+type ErrServiceInErrorState struct {
+	StackID     string
+	ServiceID   string
+	ServiceName string
+}
+
+func (e *ErrServiceInErrorState) Error() string {
+	return "stack has service '" + e.ServiceName + "' in error state"
+}
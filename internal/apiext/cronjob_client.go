@@ -0,0 +1,145 @@
+package apiext
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/cronjobclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/cronjobv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiutils"
+)
+
+type CronjobClient interface {
+	cronjobclientv2.Client
+
+	ListAllExecutions(ctx context.Context, cronjobID string) ([]cronjobv2.CronjobExecution, error)
+	ListRecentExecutions(ctx context.Context, cronjobID string, limit int64) ([]cronjobv2.CronjobExecution, error)
+	TriggerExecution(ctx context.Context, cronjobID string) (string, error)
+	AbortRunningExecution(ctx context.Context, cronjobID, executionID string) error
+	WaitUntilExecutionIsFinished(ctx context.Context, cronjobID, executionID string) (*cronjobv2.CronjobExecution, error)
+}
+
+type cronjobClient struct {
+	cronjobclientv2.Client
+}
+
+func NewCronjobClient(c mittwaldv2.Client) CronjobClient {
+	return &cronjobClient{
+		Client: c.Cronjob(),
+	}
+}
+
+// cronjobExecutionsPageSize is the page size for listing cronjob executions.
+const cronjobExecutionsPageSize = 100
+
+// ListAllExecutions returns all executions of the given cronjob, most recent
+// first (see SortCronjobExecutionsByStart).
+func (c *cronjobClient) ListAllExecutions(ctx context.Context, cronjobID string) ([]cronjobv2.CronjobExecution, error) {
+	executions, err := apiutils.FetchAllPages(ctx, cronjobExecutionsPageSize, func(ctx context.Context, limit, page int64) (*[]cronjobv2.CronjobExecution, *http.Response, error) {
+		return c.ListExecutions(ctx, cronjobclientv2.ListExecutionsRequest{
+			CronjobID: cronjobID,
+			Limit:     &limit,
+			Page:      &page,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions of cronjob %s: %w", cronjobID, err)
+	}
+
+	SortCronjobExecutionsByStart(executions)
+
+	return executions, nil
+}
+
+// ListRecentExecutions returns (at most) the last `limit` executions of the
+// given cronjob, most recent first. Since the API cannot sort executions, all
+// executions are fetched and sorted before the limit is applied.
+func (c *cronjobClient) ListRecentExecutions(ctx context.Context, cronjobID string, limit int64) ([]cronjobv2.CronjobExecution, error) {
+	executions, err := c.ListAllExecutions(ctx, cronjobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(executions)) > limit {
+		executions = executions[:limit]
+	}
+
+	return executions, nil
+}
+
+// SortCronjobExecutionsByStart sorts executions by their start time, most
+// recent first. The API does not document the order in which it lists
+// executions, so this must not be relied upon. Executions that have not
+// started yet are considered the most recent.
+func SortCronjobExecutionsByStart(executions []cronjobv2.CronjobExecution) {
+	sort.SliceStable(executions, func(i, j int) bool {
+		a, b := executions[i].Start, executions[j].Start
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+
+		return a.After(*b)
+	})
+}
+
+// TriggerExecution starts an immediate execution of the given cronjob, and
+// returns the ID of the new execution.
+func (c *cronjobClient) TriggerExecution(ctx context.Context, cronjobID string) (string, error) {
+	execution, _, err := c.CreateExecution(ctx, cronjobclientv2.CreateExecutionRequest{CronjobID: cronjobID})
+	if err != nil {
+		return "", fmt.Errorf("failed to trigger execution of cronjob %s: %w", cronjobID, err)
+	}
+
+	return execution.Id, nil
+}
+
+// AbortRunningExecution aborts the given execution of a cronjob.
+func (c *cronjobClient) AbortRunningExecution(ctx context.Context, cronjobID, executionID string) error {
+	_, err := c.AbortExecution(ctx, cronjobclientv2.AbortExecutionRequest{CronjobID: cronjobID, ExecutionID: executionID})
+	if err != nil {
+		return fmt.Errorf("failed to abort execution %s of cronjob %s: %w", executionID, cronjobID, err)
+	}
+
+	return nil
+}
+
+// WaitUntilExecutionIsFinished polls the given execution until it has ended
+// (see CronjobExecutionIsFinished), and returns its final state. Use the
+// deadline of ctx to limit the waiting time.
+func (c *cronjobClient) WaitUntilExecutionIsFinished(ctx context.Context, cronjobID, executionID string) (*cronjobv2.CronjobExecution, error) {
+	request := cronjobclientv2.GetExecutionRequest{CronjobID: cronjobID, ExecutionID: executionID}
+
+	return apiutils.Poll(ctx, apiutils.PollOpts{MaxDelay: 5 * time.Second}, func(ctx context.Context, req cronjobclientv2.GetExecutionRequest) (*cronjobv2.CronjobExecution, error) {
+		execution, _, err := c.GetExecution(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !CronjobExecutionIsFinished(execution.Status) {
+			return nil, apiutils.ErrPollShouldRetry
+		}
+
+		return execution, nil
+	}, request)
+}
+
+// CronjobExecutionIsFinished reports whether an execution with the given
+// status has ended, either successfully or not.
+func CronjobExecutionIsFinished(status cronjobv2.CronjobExecutionStatus) bool {
+	switch status {
+	case cronjobv2.CronjobExecutionStatusPending, cronjobv2.CronjobExecutionStatusRunning:
+		return false
+	default:
+		return true
+	}
+}
+
+// CronjobExecutionIsSuccessful reports whether an execution with the given
+// status has completed successfully.
+func CronjobExecutionIsSuccessful(status cronjobv2.CronjobExecutionStatus) bool {
+	return status == cronjobv2.CronjobExecutionStatusComplete
+}
@@ -0,0 +1,96 @@
+package apiext
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/cronjobclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/cronjobv2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCronjobExecutionStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(CronjobExecutionIsFinished(cronjobv2.CronjobExecutionStatusPending)).To(BeFalse())
+	g.Expect(CronjobExecutionIsFinished(cronjobv2.CronjobExecutionStatusRunning)).To(BeFalse())
+	g.Expect(CronjobExecutionIsFinished(cronjobv2.CronjobExecutionStatusComplete)).To(BeTrue())
+	g.Expect(CronjobExecutionIsFinished(cronjobv2.CronjobExecutionStatusFailed)).To(BeTrue())
+
+	g.Expect(CronjobExecutionIsSuccessful(cronjobv2.CronjobExecutionStatusComplete)).To(BeTrue())
+	g.Expect(CronjobExecutionIsSuccessful(cronjobv2.CronjobExecutionStatusFailed)).To(BeFalse())
+	g.Expect(CronjobExecutionIsSuccessful(cronjobv2.CronjobExecutionStatusRunning)).To(BeFalse())
+}
+
+func TestSortCronjobExecutionsByStart(t *testing.T) {
+	g := NewWithT(t)
+
+	at := func(hour int) *time.Time {
+		start := time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC)
+		return &start
+	}
+
+	executions := []cronjobv2.CronjobExecution{
+		{Id: "oldest", Start: at(1)},
+		{Id: "newest", Start: at(3)},
+		{Id: "pending"},
+		{Id: "middle", Start: at(2)},
+	}
+
+	SortCronjobExecutionsByStart(executions)
+
+	ids := make([]string, len(executions))
+	for i, execution := range executions {
+		ids[i] = execution.Id
+	}
+
+	g.Expect(ids).To(Equal([]string{"pending", "newest", "middle", "oldest"}))
+}
+
+// fakeCronjobExecutionsClient serves the given executions in pages, in the
+// order in which they are given.
+type fakeCronjobExecutionsClient struct {
+	cronjobclientv2.Client
+	executions []cronjobv2.CronjobExecution
+}
+
+func (f *fakeCronjobExecutionsClient) ListExecutions(_ context.Context, req cronjobclientv2.ListExecutionsRequest, _ ...func(req *http.Request) error) (*[]cronjobv2.CronjobExecution, *http.Response, error) {
+	total := int64(len(f.executions))
+	start := min((*req.Page-1)**req.Limit, total)
+	end := min(start+*req.Limit, total)
+
+	page := f.executions[start:end]
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("x-pagination-totalcount", strconv.FormatInt(total, 10))
+
+	return &page, resp, nil
+}
+
+func TestListRecentExecutionsAcrossPages(t *testing.T) {
+	g := NewWithT(t)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	executions := make([]cronjobv2.CronjobExecution, 0, cronjobExecutionsPageSize+10)
+
+	// The newest execution is listed last, outside of the first page.
+	for i := range cronjobExecutionsPageSize + 10 {
+		start := base.Add(time.Duration(i) * time.Hour)
+		executions = append(executions, cronjobv2.CronjobExecution{Id: fmt.Sprintf("execution-%d", i), Start: &start})
+	}
+
+	client := &cronjobClient{Client: &fakeCronjobExecutionsClient{executions: executions}}
+
+	recent, err := client.ListRecentExecutions(context.Background(), "cronjob", 2)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recent).To(HaveLen(2))
+	g.Expect(recent[0].Id).To(Equal(fmt.Sprintf("execution-%d", cronjobExecutionsPageSize+9)))
+	g.Expect(recent[1].Id).To(Equal(fmt.Sprintf("execution-%d", cronjobExecutionsPageSize+8)))
+
+	all, err := client.ListAllExecutions(context.Background(), "cronjob")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(all).To(HaveLen(cronjobExecutionsPageSize + 10))
+}
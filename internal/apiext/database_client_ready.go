@@ -0,0 +1,55 @@
+package apiext
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/databaseclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/databasev2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiutils"
+)
+
+// ErrMySQLDatabaseInErrorState is returned by WaitUntilMySQLDatabaseIsReady
+// when the awaited database has entered the error state.
+type ErrMySQLDatabaseInErrorState struct {
+	DatabaseID string
+}
+
+func (e *ErrMySQLDatabaseInErrorState) Error() string {
+	return "database " + e.DatabaseID + " is in error state"
+}
+
+// WaitUntilMySQLDatabaseIsReady waits until the specified MySQL database is
+// ready and (if check is not nil) satisfies check; this is used after
+// asynchronous operations like copying or upgrading a database.
+//
+// If the database enters the error state, an *ErrMySQLDatabaseInErrorState is
+// returned. The wait is only bounded by ctx, so callers should set a deadline.
+func WaitUntilMySQLDatabaseIsReady(ctx context.Context, client databaseclientv2.Client, databaseID string, check func(database *databasev2.MySqlDatabase) bool) (*databasev2.MySqlDatabase, error) {
+	request := databaseclientv2.GetMysqlDatabaseRequest{MysqlDatabaseID: databaseID}
+
+	runner := func(ctx context.Context, req databaseclientv2.GetMysqlDatabaseRequest, reqEditors ...func(req *http.Request) error) (*databasev2.MySqlDatabase, *http.Response, error) {
+		database, resp, err := client.GetMysqlDatabase(ctx, req, reqEditors...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if database.Status == databasev2.DatabaseStatusError {
+			return nil, nil, &ErrMySQLDatabaseInErrorState{DatabaseID: databaseID}
+		}
+
+		if database.Status != databasev2.DatabaseStatusReady || (check != nil && !check(database)) {
+			return nil, nil, apiutils.ErrPollShouldRetry
+		}
+
+		return database, resp, nil
+	}
+
+	o := apiutils.PollOpts{
+		InitialDelay: 1 * time.Second,
+		MaxDelay:     30 * time.Second,
+	}
+
+	return apiutils.PollRequest(ctx, o, runner, request)
+}
@@ -0,0 +1,127 @@
+package apiext
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/databaseclientv2"
+)
+
+// DatabaseVersion is a version of a database engine (like MySQL or Redis)
+// that is offered by the platform.
+type DatabaseVersion struct {
+	ID     string
+	Name   string
+	Number string
+
+	// Disabled is set for versions that are deprecated or have reached their
+	// end of life; these can no longer be used for new databases.
+	Disabled bool
+}
+
+type DatabaseVersionSet []DatabaseVersion
+
+func (s DatabaseVersionSet) Len() int {
+	return len(s)
+}
+
+func (s DatabaseVersionSet) Less(i, j int) bool {
+	verI, errI := semver.NewVersion(s[i].Number)
+	verJ, errJ := semver.NewVersion(s[j].Number)
+
+	if errI != nil || errJ != nil {
+		return s[i].Number < s[j].Number
+	}
+
+	return verI.LessThan(verJ)
+}
+
+func (s DatabaseVersionSet) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Recommended returns the latest version that is not disabled.
+func (s DatabaseVersionSet) Recommended() (*DatabaseVersion, bool) {
+	sorted := append(DatabaseVersionSet(nil), s...)
+	sort.Sort(sorted)
+
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if !sorted[i].Disabled {
+			return &sorted[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// FilterByConstraintStr returns the versions matching a version selector (like
+// "~8.0"), sorted in ascending order.
+func (s DatabaseVersionSet) FilterByConstraintStr(c string) (DatabaseVersionSet, error) {
+	selector, err := semver.NewConstraint(c)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version selector '%s': %w", c, err)
+	}
+
+	var filtered DatabaseVersionSet
+	for _, version := range s {
+		v, err := semver.NewVersion(version.Number)
+		if err != nil {
+			continue
+		}
+		if selector.Check(v) {
+			filtered = append(filtered, version)
+		}
+	}
+
+	sort.Sort(filtered)
+
+	return filtered, nil
+}
+
+// ListMySQLVersions lists the MySQL versions that are available for the given
+// project; if projectID is empty, all versions are listed.
+func ListMySQLVersions(ctx context.Context, client databaseclientv2.Client, projectID string) (DatabaseVersionSet, error) {
+	req := databaseclientv2.ListMysqlVersionsRequest{}
+	if projectID != "" {
+		req.ProjectID = &projectID
+	}
+
+	versions, _, err := client.ListMysqlVersions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(DatabaseVersionSet, 0, len(*versions))
+	for _, version := range *versions {
+		set = append(set, DatabaseVersion{ID: version.Id, Name: version.Name, Number: version.Number, Disabled: version.Disabled})
+	}
+
+	sort.Sort(set)
+
+	return set, nil
+}
+
+// ListRedisVersions lists the Redis versions that are available for the given
+// project; if projectID is empty, all versions are listed.
+func ListRedisVersions(ctx context.Context, client databaseclientv2.Client, projectID string) (DatabaseVersionSet, error) {
+	req := databaseclientv2.ListRedisVersionsRequest{}
+	if projectID != "" {
+		req.ProjectID = &projectID
+	}
+
+	versions, _, err := client.ListRedisVersions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(DatabaseVersionSet, 0, len(*versions))
+	for _, version := range *versions {
+		set = append(set, DatabaseVersion{ID: version.Id, Name: version.Name, Number: version.Number, Disabled: version.Disabled})
+	}
+
+	sort.Sort(set)
+
+	return set, nil
+}
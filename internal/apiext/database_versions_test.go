@@ -0,0 +1,58 @@
+package apiext
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDatabaseVersionSet(t *testing.T) {
+	versions := DatabaseVersionSet{
+		{ID: "3", Number: "8.4"},
+		{ID: "1", Number: "5.7", Disabled: true},
+		{ID: "2", Number: "8.0"},
+		{ID: "4", Number: "9.0", Disabled: true},
+	}
+
+	numbers := func(s DatabaseVersionSet) []string {
+		result := make([]string, len(s))
+		for i, v := range s {
+			result[i] = v.Number
+		}
+		return result
+	}
+
+	t.Run("recommended is the latest enabled version", func(t *testing.T) {
+		g := NewWithT(t)
+
+		recommended, ok := versions.Recommended()
+		g.Expect(ok).To(BeTrue())
+		g.Expect(recommended.Number).To(Equal("8.4"))
+	})
+
+	t.Run("no recommended version if all are disabled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, ok := DatabaseVersionSet{{Number: "5.7", Disabled: true}}.Recommended()
+		g.Expect(ok).To(BeFalse())
+	})
+
+	t.Run("filter by constraint", func(t *testing.T) {
+		g := NewWithT(t)
+
+		filtered, err := versions.FilterByConstraintStr("~8.0")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(numbers(filtered)).To(Equal([]string{"8.0"}))
+
+		filtered, err = versions.FilterByConstraintStr(">= 8")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(numbers(filtered)).To(Equal([]string{"8.0", "8.4", "9.0"}))
+	})
+
+	t.Run("invalid constraint", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := versions.FilterByConstraintStr("not a version")
+		g.Expect(err).To(HaveOccurred())
+	})
+}
@@ -0,0 +1,152 @@
+package apiext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/appclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/containerclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/projectclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/userclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/projectv2"
+)
+
+// SSHTarget identifies the app installation, container or project that an SSH
+// connection should be opened to. Exactly one of AppID, ContainerID (together
+// with StackID) or ProjectID must be set.
+type SSHTarget struct {
+	AppID       string
+	ContainerID string
+	StackID     string
+
+	// ProjectID selects a connection to the project itself, which is not bound
+	// to any specific app installation; this is useful for working with
+	// project-level services like databases.
+	ProjectID string
+
+	// User is the SSH user name, without the "@<short-id>" suffix. If empty, the
+	// email address of the currently authenticated user is used.
+	User string
+}
+
+// SSHConnectionDetails contains the host and user name to use for connecting
+// to an SSHTarget.
+type SSHConnectionDetails struct {
+	Host      string
+	User      string
+	ProjectID string
+
+	// InstallationPathAbsolute is the absolute path of the app installation on
+	// the SSH host; only set for app installation targets.
+	InstallationPathAbsolute string
+}
+
+// ResolveSSHConnectionDetails determines the SSH host and user name for the
+// given target. The SSH host is determined from the cluster of the project that
+// the target belongs to; the user name is always formatted as
+// "<username>@<short-id>".
+func ResolveSSHConnectionDetails(ctx context.Context, client mittwaldv2.Client, target SSHTarget) (*SSHConnectionDetails, error) {
+	info, err := resolveSSHTargetInfo(ctx, client, target)
+	if err != nil {
+		return nil, err
+	}
+
+	project, _, err := client.Project().GetProject(ctx, projectclientv2.GetProjectRequest{ProjectID: info.projectID})
+	if err != nil {
+		return nil, fmt.Errorf("error getting project details: %w", err)
+	}
+
+	host, err := projectSSHHost(project)
+	if err != nil {
+		return nil, err
+	}
+
+	username := target.User
+	if username == "" {
+		username, err = authenticatedUserEmail(ctx, client)
+		if err != nil {
+			return nil, fmt.Errorf("error determining SSH username: %w", err)
+		}
+	}
+
+	details := SSHConnectionDetails{
+		Host:      host,
+		User:      fmt.Sprintf("%s@%s", username, info.shortID),
+		ProjectID: info.projectID,
+	}
+
+	if target.AppID != "" {
+		details.InstallationPathAbsolute = project.Directories["Web"] + info.installationPath
+	}
+
+	return &details, nil
+}
+
+func projectSSHHost(project *projectv2.Project) (string, error) {
+	if project.ClusterID != nil && project.ClusterDomain != nil {
+		return fmt.Sprintf("ssh.%s.%s", *project.ClusterID, *project.ClusterDomain), nil
+	}
+
+	return "", fmt.Errorf("project %s does not have cluster information", project.Id)
+}
+
+type sshTargetInfo struct {
+	projectID        string
+	shortID          string
+	installationPath string
+}
+
+func resolveSSHTargetInfo(ctx context.Context, client mittwaldv2.Client, target SSHTarget) (*sshTargetInfo, error) {
+	if target.ContainerID != "" && target.StackID != "" {
+		container, _, err := client.Container().GetService(ctx, containerclientv2.GetServiceRequest{
+			ServiceID: target.ContainerID,
+			StackID:   target.StackID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error getting container details: %w", err)
+		}
+
+		return &sshTargetInfo{projectID: container.ProjectId, shortID: container.ShortId}, nil
+	}
+
+	if target.AppID != "" {
+		appInstallation, _, err := client.App().GetAppinstallation(ctx, appclientv2.GetAppinstallationRequest{
+			AppInstallationID: target.AppID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error getting app installation details: %w", err)
+		}
+
+		return &sshTargetInfo{
+			projectID:        appInstallation.ProjectId,
+			shortID:          appInstallation.ShortId,
+			installationPath: appInstallation.InstallationPath,
+		}, nil
+	}
+
+	if target.ProjectID != "" {
+		project, _, err := client.Project().GetProject(ctx, projectclientv2.GetProjectRequest{ProjectID: target.ProjectID})
+		if err != nil {
+			return nil, fmt.Errorf("error getting project details: %w", err)
+		}
+
+		return &sshTargetInfo{projectID: project.Id, shortID: project.ShortId}, nil
+	}
+
+	return nil, errors.New("either container_id+stack_id, app_id or project_id must be specified")
+}
+
+func authenticatedUserEmail(ctx context.Context, client mittwaldv2.Client) (string, error) {
+	user, _, err := client.User().GetUser(ctx, userclientv2.GetUserRequest{UserID: "self"})
+	if err != nil {
+		return "", fmt.Errorf("error getting user details: %w", err)
+	}
+
+	if user.Email != nil {
+		return *user.Email, nil
+	}
+
+	return "", errors.New("user email is not available, cannot determine SSH username")
+}
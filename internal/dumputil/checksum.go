@@ -0,0 +1,92 @@
+package dumputil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ChecksumFileSuffix is appended to the path of a dump to get the path of the
+// file that its SHA-256 checksum is written to.
+const ChecksumFileSuffix = ".sha256"
+
+// WriteChecksumFile writes the SHA-256 checksum of the dump at path to the
+// checksum file next to it, in the format of sha256sum (see
+// ChecksumFileSuffix). The checksum file is written to a temporary file first
+// and then renamed, so that it is never incomplete.
+func WriteChecksumFile(path, checksum string) error {
+	line := fmt.Sprintf("%s  %s\n", checksum, filepath.Base(path))
+	return writeFileAtomic(path+ChecksumFileSuffix, []byte(line))
+}
+
+// VerifyDumpFile verifies the dump at path against the checksum file that was
+// written next to it, and returns its SHA-256 checksum and its size in bytes.
+func VerifyDumpFile(path string) (string, int64, error) {
+	raw, err := os.ReadFile(path + ChecksumFileSuffix)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read checksum file: %w", err)
+	}
+
+	fields := strings.Fields(string(raw))
+	if len(fields) != 2 || fields[1] != filepath.Base(path) {
+		return "", 0, fmt.Errorf("checksum file %s%s is not a valid checksum file for %s", path, ChecksumFileSuffix, filepath.Base(path))
+	}
+
+	expected := fields[0]
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open dump file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read dump file: %w", err)
+	}
+
+	checksum := hex.EncodeToString(h.Sum(nil))
+	if checksum != expected {
+		return "", 0, fmt.Errorf("the SHA-256 checksum of %s is %s, but %s was expected; the dump is incomplete or was modified", path, checksum, expected)
+	}
+
+	return checksum, size, nil
+}
+
+// writeFileAtomic writes contents to a temporary file next to destination and
+// then renames it to destination.
+func writeFileAtomic(destination string, contents []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(destination), "."+filepath.Base(destination)+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(contents); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), destination); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return nil
+}
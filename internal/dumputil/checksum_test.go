@@ -0,0 +1,46 @@
+package dumputil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestVerifyDumpFile(t *testing.T) {
+	g := NewWithT(t)
+
+	destination := filepath.Join(t.TempDir(), "dump.sql")
+
+	_, _, err := VerifyDumpFile(destination)
+	g.Expect(err).To(MatchError(ContainSubstring("failed to read checksum file")))
+
+	contents := []byte("CREATE TABLE t (id INT);\n")
+	sum := sha256.Sum256(contents)
+	checksum := hex.EncodeToString(sum[:])
+
+	g.Expect(os.WriteFile(destination, contents, 0o600)).To(Succeed())
+	g.Expect(WriteChecksumFile(destination, checksum)).To(Succeed())
+
+	checksumFile, err := os.ReadFile(destination + ChecksumFileSuffix)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(checksumFile)).To(Equal(checksum + "  dump.sql\n"))
+
+	verified, size, err := VerifyDumpFile(destination)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(verified).To(Equal(checksum))
+	g.Expect(size).To(BeEquivalentTo(len(contents)))
+
+	g.Expect(os.WriteFile(destination, []byte("CREATE TABLE t"), 0o600)).To(Succeed())
+
+	_, _, err = VerifyDumpFile(destination)
+	g.Expect(err).To(MatchError(ContainSubstring("the dump is incomplete or was modified")))
+
+	g.Expect(os.WriteFile(destination+ChecksumFileSuffix, []byte("garbage"), 0o600)).To(Succeed())
+
+	_, _, err = VerifyDumpFile(destination)
+	g.Expect(err).To(MatchError(ContainSubstring("is not a valid checksum file")))
+}
@@ -0,0 +1,15 @@
+package actionutil
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFormatBytes(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(FormatBytes(512)).To(Equal("512 B"))
+	g.Expect(FormatBytes(1536)).To(Equal("1.5 KiB"))
+	g.Expect(FormatBytes(12 * 1024 * 1024)).To(Equal("12.0 MiB"))
+}
@@ -0,0 +1,155 @@
+package actionutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alessio/shellescape"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/databaseclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/databasev2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
+	"golang.org/x/crypto/ssh"
+)
+
+// MySQLCredentials are the connection parameters for the MySQL command line
+// tools (like mysql or mysqldump).
+type MySQLCredentials struct {
+	Host     string
+	User     string
+	Password string
+}
+
+// OptionFile returns the credentials in the format of a MySQL option file,
+// for use with --defaults-extra-file. Passing the password this way keeps it
+// out of the process list of the SSH host.
+func (c MySQLCredentials) OptionFile() []byte {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	return []byte(fmt.Sprintf(
+		"[client]\nhost=\"%s\"\nuser=\"%s\"\npassword=\"%s\"\n",
+		quote.Replace(c.Host),
+		quote.Replace(c.User),
+		quote.Replace(c.Password),
+	))
+}
+
+// WriteMySQLOptionFile writes the credentials to a temporary option file in
+// the home directory of the SSH user and returns its path, together with a
+// function that removes the file again.
+func WriteMySQLOptionFile(session *sshutil.SFTPSession, credentials MySQLCredentials) (string, func() error, error) {
+	path, err := sshutil.WriteSecretFile(session.Client, ".", ".terraform-mysql-", credentials.OptionFile())
+	if err != nil {
+		return "", nil, err
+	}
+
+	cleanup := func() error {
+		return session.Remove(path)
+	}
+
+	return path, cleanup, nil
+}
+
+// MySQLConnectionParams identifies a MySQL database, the credentials to access
+// it with and the SSH credentials for connecting to its project.
+type MySQLConnectionParams struct {
+	DatabaseID    string
+	User          string
+	Password      string
+	SSHUser       string
+	SSHPrivateKey string
+}
+
+// MySQLConnection is an SSH connection to the project of a MySQL database,
+// from which the MySQL command line tools can be run using OptionFile.
+type MySQLConnection struct {
+	Database   *databasev2.MySqlDatabase
+	Session    *sshutil.SFTPSession
+	OptionFile string
+
+	removeOptionFile func() error
+}
+
+// ConnectMySQL looks up the given MySQL database, opens an SSH connection to
+// its project and writes a temporary option file with the credentials (see
+// WriteMySQLOptionFile). Errors are reported with the given summary; the
+// returned connection must be closed by the caller.
+func ConnectMySQL(ctx context.Context, client mittwaldv2.Client, pool *sshutil.Pool, params MySQLConnectionParams, summary string, d *diag.Diagnostics) *MySQLConnection {
+	database := providerutil.
+		Try[*databasev2.MySqlDatabase](d, summary).
+		DoValResp(client.Database().GetMysqlDatabase(ctx, databaseclientv2.GetMysqlDatabaseRequest{MysqlDatabaseID: params.DatabaseID}))
+
+	if d.HasError() {
+		return nil
+	}
+
+	details, err := apiext.ResolveSSHConnectionDetails(ctx, client, apiext.SSHTarget{
+		ProjectID: database.ProjectId,
+		User:      params.SSHUser,
+	})
+	if err != nil {
+		d.AddError(summary, "Could not determine SSH connection details: "+err.Error())
+		return nil
+	}
+
+	session, err := pool.AcquireSFTP(ctx, details.Host, details.User, params.SSHPrivateKey, nil, d)
+	if err != nil {
+		d.AddError(summary, "Could not connect to project via SSH: "+err.Error())
+		return nil
+	}
+
+	optionFile, removeOptionFile, err := WriteMySQLOptionFile(session, MySQLCredentials{
+		Host:     database.Hostname,
+		User:     params.User,
+		Password: params.Password,
+	})
+	if err != nil {
+		_ = session.Close()
+		d.AddError(summary, "Could not write MySQL credentials: "+err.Error())
+		return nil
+	}
+
+	return &MySQLConnection{
+		Database:         database,
+		Session:          session,
+		OptionFile:       optionFile,
+		removeOptionFile: removeOptionFile,
+	}
+}
+
+// Command builds a command line for one of the MySQL command line tools (like
+// mysql or mysqldump) that uses the credentials of the connection; args are
+// quoted as necessary.
+func (c *MySQLConnection) Command(tool string, args ...string) string {
+	parts := []string{tool, "--defaults-extra-file=" + shellescape.Quote(c.OptionFile)}
+	for _, arg := range args {
+		parts = append(parts, shellescape.Quote(arg))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Client returns the SSH connection to run the MySQL command line tools on.
+func (c *MySQLConnection) Client() *ssh.Client {
+	return c.Session.SSHClient()
+}
+
+// Close removes the option file and releases the SSH connection. Since the
+// option file contains the credentials, a warning with its path is added to d
+// if it cannot be removed.
+func (c *MySQLConnection) Close(ctx context.Context, d *diag.Diagnostics) {
+	if err := c.removeOptionFile(); err != nil {
+		tflog.Warn(ctx, "could not remove MySQL option file", map[string]any{"path": c.OptionFile, "error": err.Error()})
+		d.AddWarning(
+			"Could not remove MySQL credentials",
+			fmt.Sprintf("The temporary file %s on the SSH host contains the credentials of the database user, but could not be removed: %s. Please remove it manually.", c.OptionFile, err),
+		)
+	}
+
+	_ = c.Session.Close()
+}
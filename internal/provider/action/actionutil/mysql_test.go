@@ -0,0 +1,24 @@
+package actionutil
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMySQLCredentialsOptionFile(t *testing.T) {
+	g := NewWithT(t)
+
+	credentials := MySQLCredentials{
+		Host:     "mysql-abc123.pg-s-xyz.db.project.host",
+		User:     "dbu_abc123",
+		Password: `pa"ss\word`,
+	}
+
+	g.Expect(string(credentials.OptionFile())).To(Equal(
+		"[client]\n" +
+			"host=\"mysql-abc123.pg-s-xyz.db.project.host\"\n" +
+			"user=\"dbu_abc123\"\n" +
+			"password=\"pa\\\"ss\\\\word\"\n",
+	))
+}
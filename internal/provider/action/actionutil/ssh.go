@@ -0,0 +1,122 @@
+package actionutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mittwald/terraform-provider-mittwald/internal/shellutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
+	"golang.org/x/crypto/ssh"
+)
+
+// OutputTailLines is the number of output lines that are included in the error
+// diagnostic when a remote command fails.
+const OutputTailLines = 50
+
+// ParseTimeout parses the optional `timeout` attribute of an action. If the
+// attribute is not set, def is returned.
+func ParseTimeout(value types.String, def time.Duration, d *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return def
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil || timeout <= 0 {
+		d.AddAttributeError(path.Root("timeout"), "Invalid timeout", fmt.Sprintf("timeout must be a positive duration, like \"5m\"; got %q", value.ValueString()))
+		return def
+	}
+
+	return timeout
+}
+
+// Progress sends progress events for an action; unlike the InvokeResponse
+// itself, it may be used concurrently.
+type Progress struct {
+	mu   sync.Mutex
+	resp *action.InvokeResponse
+}
+
+func NewProgress(resp *action.InvokeResponse) *Progress {
+	return &Progress{resp: resp}
+}
+
+// Send logs the given message and sends it to Terraform as a progress event.
+func (p *Progress) Send(ctx context.Context, message string, additionalFields ...map[string]any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tflog.Info(ctx, message, additionalFields...)
+	p.resp.SendProgress(action.InvokeProgressEvent{Message: message})
+}
+
+// RunSSHCommand runs a command via SSH on behalf of an action. Each line of
+// output is logged and sent to Terraform as a progress event; if the command
+// fails (either by a non-zero exit status or by exceeding the deadline of ctx),
+// an error diagnostic with the given summary and the most recent output is
+// added to resp.
+func RunSSHCommand(ctx context.Context, client *ssh.Client, command string, stdin io.Reader, summary string, resp *action.InvokeResponse) {
+	RunSSHCommandWithProgress(ctx, client, command, stdin, summary, resp, NewProgress(resp))
+}
+
+// RunSSHCommandWithProgress is like RunSSHCommand, but sends the command output
+// through the given progress, so that the caller may send additional progress
+// events while the command is running.
+func RunSSHCommandWithProgress(ctx context.Context, client *ssh.Client, command string, stdin io.Reader, summary string, resp *action.InvokeResponse, progress *Progress) {
+	tail := sshutil.NewOutputTail(OutputTailLines)
+
+	ctx = tflog.SetField(ctx, "command", shellutil.RedactExportedValues(command))
+	tflog.Debug(ctx, "running remote command")
+
+	err := sshutil.RunCommand(ctx, client, command, stdin, func(stream sshutil.OutputStream, line string) {
+		tail.Add(stream, line)
+		progress.Send(ctx, line, map[string]any{"stream": stream})
+	})
+
+	addCommandError(err, tail, summary, resp)
+}
+
+// StreamSSHCommand runs a command via SSH on behalf of an action, and copies
+// its standard output to stdout. Each line of the standard error is logged
+// and sent to Terraform as a progress event; errors are reported like in
+// RunSSHCommand.
+func StreamSSHCommand(ctx context.Context, client *ssh.Client, command string, stdout io.Writer, summary string, resp *action.InvokeResponse, progress *Progress) {
+	tail := sshutil.NewOutputTail(OutputTailLines)
+
+	ctx = tflog.SetField(ctx, "command", shellutil.RedactExportedValues(command))
+	tflog.Debug(ctx, "running remote command")
+
+	err := sshutil.StreamCommand(ctx, client, command, stdout, func(stream sshutil.OutputStream, line string) {
+		tail.Add(stream, line)
+		progress.Send(ctx, line, map[string]any{"stream": stream})
+	})
+
+	addCommandError(err, tail, summary, resp)
+}
+
+func addCommandError(err error, tail *sshutil.OutputTail, summary string, resp *action.InvokeResponse) {
+	var exitErr *ssh.ExitError
+
+	switch {
+	case errors.As(err, &exitErr):
+		resp.Diagnostics.AddError(
+			summary,
+			fmt.Sprintf("Command exited with status %d. Most recent output (up to %d lines):\n\n%s", exitErr.ExitStatus(), OutputTailLines, tail.String()),
+		)
+	case errors.Is(err, context.DeadlineExceeded):
+		resp.Diagnostics.AddError(
+			summary,
+			fmt.Sprintf("Command did not complete in time. Most recent output (up to %d lines):\n\n%s", OutputTailLines, tail.String()),
+		)
+	case err != nil:
+		resp.Diagnostics.AddError(summary, "An error was encountered while running the command: "+err.Error())
+	}
+}
@@ -0,0 +1,135 @@
+package appexecaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/actionutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/shellutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
+)
+
+var _ action.Action = &Action{}
+
+// DefaultTimeout is the maximum duration of a command, unless specified
+// otherwise.
+const DefaultTimeout = 10 * time.Minute
+
+type Action struct {
+	client  mittwaldv2.Client
+	sshPool *sshutil.Pool
+}
+
+func New() action.Action {
+	return &Action{}
+}
+
+type ExecModel struct {
+	AppID         types.String `tfsdk:"app_id"`
+	Command       types.String `tfsdk:"command"`
+	Environment   types.Map    `tfsdk:"environment"`
+	Timeout       types.String `tfsdk:"timeout"`
+	SSHUser       types.String `tfsdk:"ssh_user"`
+	SSHPrivateKey types.String `tfsdk:"ssh_private_key"`
+}
+
+func (a *Action) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs a shell command in the installation directory of an app installation via SSH. The action fails if the command exits with a non-zero exit status.",
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				Description: "ID of the app installation in which to run the command",
+				Required:    true,
+			},
+			"command": schema.StringAttribute{
+				Description: "The shell command to run; it is run from the installation directory of the app (installation_path_absolute)",
+				Required:    true,
+			},
+			"environment": schema.MapAttribute{
+				Description: "Environment variables to set for the command",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Maximum duration of the command, as a Go duration string (like \"30s\" or \"5m\"); defaults to \"" + DefaultTimeout.String() + "\"",
+				Optional:    true,
+			},
+			"ssh_user": schema.StringAttribute{
+				Description: "The SSH username to use for the connection; defaults to the currently authenticated user",
+				Optional:    true,
+			},
+			"ssh_private_key": schema.StringAttribute{
+				Description: "The SSH private key to use for the connection; if not specified, " + sshutil.DefaultAuthDescription,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *Action) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+	a.sshPool = providerutil.SSHPoolFromProviderData(req.ProviderData)
+}
+
+func (a *Action) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_exec"
+}
+
+func (a *Action) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	params := &ExecModel{}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &params)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	env := make(map[string]string)
+	if !params.Environment.IsNull() {
+		resp.Diagnostics.Append(params.Environment.ElementsAs(ctx, &env, false)...)
+	}
+
+	for name := range env {
+		if err := shellutil.ValidateEnvironmentVariableName(name); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("environment").AtMapKey(name), "Invalid environment variable", err.Error())
+		}
+	}
+
+	timeout := actionutil.ParseTimeout(params.Timeout, DefaultTimeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	details, err := apiext.ResolveSSHConnectionDetails(ctx, a.client, apiext.SSHTarget{
+		AppID: params.AppID.ValueString(),
+		User:  params.SSHUser.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("App Exec Error", "Could not determine SSH connection details: "+err.Error())
+		return
+	}
+
+	command, err := shellutil.BuildShellCommand(details.InstallationPathAbsolute, env, params.Command.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("App Exec Error", err.Error())
+		return
+	}
+
+	lease, err := a.sshPool.Acquire(ctx, details.Host, details.User, params.SSHPrivateKey.ValueString(), nil, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError("App Exec Error", "Could not connect to app installation via SSH: "+err.Error())
+		return
+	}
+	defer lease.Release()
+
+	actionutil.RunSSHCommand(ctx, lease.Client, command, nil, "App Exec Error", resp)
+}
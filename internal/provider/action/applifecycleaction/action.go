@@ -0,0 +1,166 @@
+package applifecycleaction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/actionutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+)
+
+var _ action.Action = &Action{}
+
+// DefaultTimeout is the maximum duration until the app installation is ready
+// again, unless specified otherwise.
+const DefaultTimeout = 10 * time.Minute
+
+// Action runs a lifecycle action (start, stop or restart) on an app
+// installation; use NewStart, NewStop or NewRestart to create one.
+type Action struct {
+	client mittwaldv2.Client
+
+	action      appv2.Action
+	name        string
+	description string
+	progress    string
+	waiting     string
+	errorTitle  string
+
+	// reached reports whether the runtime status of the app installation
+	// reflects the action, which was requested at the given time.
+	reached func(status *appv2.AppInstallationStatus, requested time.Time) bool
+}
+
+func isRunning(status *appv2.AppInstallationStatus, _ time.Time) bool {
+	return status.State == appv2.AppInstallationStatusStateRunning
+}
+
+func isStopped(status *appv2.AppInstallationStatus, _ time.Time) bool {
+	return status.State != appv2.AppInstallationStatusStateRunning
+}
+
+func NewStart() action.Action {
+	return &Action{
+		action:      appv2.ActionStart,
+		name:        "start",
+		errorTitle:  "App Start Error",
+		description: "Starts an app installation, and waits until the app is running and the installation is ready again.",
+		progress:    "Starting app installation",
+		waiting:     "Waiting for the app to be running",
+		reached:     isRunning,
+	}
+}
+
+func NewStop() action.Action {
+	return &Action{
+		action:      appv2.ActionStop,
+		name:        "stop",
+		errorTitle:  "App Stop Error",
+		description: "Stops an app installation, and waits until the app is stopped and the installation is ready again.",
+		progress:    "Stopping app installation",
+		waiting:     "Waiting for the app to be stopped",
+		reached:     isStopped,
+	}
+}
+
+func NewRestart() action.Action {
+	return &Action{
+		action:      appv2.ActionRestart,
+		name:        "restart",
+		errorTitle:  "App Restart Error",
+		description: "Restarts an app installation, and waits until the app is running again and the installation is ready again. This is useful for apps like Node.js or Python apps that need to be restarted to pick up configuration changes.",
+		progress:    "Restarting app installation",
+		waiting:     "Waiting for the app to be restarted",
+		reached:     apiext.AppInstallationHasRestartedSince,
+	}
+}
+
+type LifecycleModel struct {
+	AppID   types.String `tfsdk:"app_id"`
+	Timeout types.String `tfsdk:"timeout"`
+}
+
+func (a *Action) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: a.description,
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				Description: "ID of the app installation to " + a.name,
+				Required:    true,
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Maximum duration until the app installation is ready again, as a Go duration string (like \"30s\" or \"5m\"); defaults to \"" + DefaultTimeout.String() + "\"",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *Action) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (a *Action) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_" + a.name
+}
+
+func (a *Action) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	params := &LifecycleModel{}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &params)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := actionutil.ParseTimeout(params.Timeout, DefaultTimeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	appClient := apiext.NewAppClient(a.client)
+	appInstallationID := params.AppID.ValueString()
+	a.sendProgress(ctx, resp, a.progress)
+
+	requested := time.Now()
+
+	if err := appClient.RunAppInstallationAction(ctx, appInstallationID, a.action); err != nil {
+		resp.Diagnostics.AddError(a.errorTitle, fmt.Sprintf("An error was encountered while requesting the app installation to %s: %s", a.name, err))
+		return
+	}
+
+	// The installation usually stays in the "ready" phase while the action is
+	// carried out, so waiting for that phase alone would return immediately;
+	// wait for the runtime status of the app to reflect the action first.
+	a.sendProgress(ctx, resp, a.waiting)
+
+	err := appClient.WaitUntilAppInstallationStatus(ctx, appInstallationID, func(status *appv2.AppInstallationStatus) bool {
+		return a.reached(status, requested)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(a.errorTitle, fmt.Sprintf("The app installation was requested to %s, but this did not take effect: %s", a.name, err))
+		return
+	}
+
+	a.sendProgress(ctx, resp, "Waiting for the app installation to be ready")
+
+	if err := appClient.WaitUntilAppInstallationIsReady(ctx, appInstallationID); err != nil {
+		resp.Diagnostics.AddError(a.errorTitle, "The app installation did not become ready: "+err.Error())
+		return
+	}
+}
+
+func (a *Action) sendProgress(ctx context.Context, resp *action.InvokeResponse, message string) {
+	tflog.Info(ctx, message)
+	resp.SendProgress(action.InvokeProgressEvent{Message: message})
+}
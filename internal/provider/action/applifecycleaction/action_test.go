@@ -0,0 +1,39 @@
+package applifecycleaction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	. "github.com/onsi/gomega"
+)
+
+func TestStatusChecks(t *testing.T) {
+	requested := time.Now().Add(-30 * time.Second)
+	uptime := func(seconds float64) *float64 { return &seconds }
+
+	tests := []struct {
+		name      string
+		status    appv2.AppInstallationStatus
+		running   bool
+		stopped   bool
+		restarted bool
+	}{
+		{name: "stopped", status: appv2.AppInstallationStatus{State: appv2.AppInstallationStatusStateStopped}, stopped: true},
+		{name: "exited", status: appv2.AppInstallationStatus{State: appv2.AppInstallationStatusStateExited}, stopped: true},
+		{name: "running before restart", status: appv2.AppInstallationStatus{State: appv2.AppInstallationStatusStateRunning, UptimeSeconds: uptime(3600)}, running: true},
+		{name: "running after restart", status: appv2.AppInstallationStatus{State: appv2.AppInstallationStatusStateRunning, UptimeSeconds: uptime(5)}, running: true, restarted: true},
+		{name: "running without uptime", status: appv2.AppInstallationStatus{State: appv2.AppInstallationStatusStateRunning}, running: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(isRunning(&test.status, requested)).To(Equal(test.running))
+			g.Expect(isStopped(&test.status, requested)).To(Equal(test.stopped))
+			g.Expect(apiext.AppInstallationHasRestartedSince(&test.status, requested)).To(Equal(test.restarted))
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package appupgradeaction
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/appclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/actionutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+)
+
+var _ action.Action = &Action{}
+
+// DefaultTimeout is the maximum duration of an upgrade, unless specified
+// otherwise.
+const DefaultTimeout = 30 * time.Minute
+
+type Action struct {
+	client mittwaldv2.Client
+}
+
+func New() action.Action {
+	return &Action{}
+}
+
+type UpgradeModel struct {
+	AppID   types.String `tfsdk:"app_id"`
+	Version types.String `tfsdk:"version"`
+	Timeout types.String `tfsdk:"timeout"`
+}
+
+func (a *Action) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Upgrades an app installation to the latest version that matches a version selector, and waits until the upgrade is complete. The action fails if there is no supported upgrade path from the currently installed version to any matching version.",
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				Description: "ID of the app installation to upgrade",
+				Required:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "A version selector, such as \"~6.5\" or \">= 13.4\", or an exact version; the installation is upgraded to the latest matching version that is a supported upgrade target of the currently installed version",
+				Required:    true,
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Maximum duration of the upgrade, as a Go duration string (like \"30s\" or \"5m\"); defaults to \"" + DefaultTimeout.String() + "\"",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *Action) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (a *Action) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_upgrade"
+}
+
+func (a *Action) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	params := &UpgradeModel{}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &params)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := actionutil.ParseTimeout(params.Timeout, DefaultTimeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	appClient := apiext.NewAppClient(a.client)
+	appInstallationID := params.AppID.ValueString()
+
+	installation := providerutil.
+		Try[*appv2.AppInstallation](&resp.Diagnostics, "App Upgrade Error").
+		DoValResp(appClient.GetAppinstallation(ctx, appclientv2.GetAppinstallationRequest{AppInstallationID: appInstallationID}))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if installation.AppVersion.Current == nil {
+		resp.Diagnostics.AddError("App Upgrade Error", "The app installation has no installed version yet; wait for the installation to complete before upgrading it.")
+		return
+	}
+
+	currentVersionID := *installation.AppVersion.Current
+
+	currentVersion := providerutil.
+		Try[*appv2.AppVersion](&resp.Diagnostics, "App Upgrade Error").
+		DoValResp(appClient.GetAppversion(ctx, appclientv2.GetAppversionRequest{AppID: installation.AppId, AppVersionID: currentVersionID}))
+
+	versions := providerutil.
+		Try[apiext.AppVersionSet](&resp.Diagnostics, "App Upgrade Error").
+		DoVal(appClient.SelectAppVersion(ctx, installation.AppId, params.Version.ValueString()))
+
+	candidates := providerutil.
+		Try[apiext.AppVersionSet](&resp.Diagnostics, "App Upgrade Error").
+		DoVal(appClient.ListAppUpdateCandidates(ctx, installation.AppId, currentVersionID))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(versions) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("version"), "No Matching Version", fmt.Sprintf("There is no version matching %q.", params.Version.ValueString()))
+		return
+	}
+
+	if versions[len(versions)-1].Id == currentVersionID {
+		a.progress(ctx, resp, fmt.Sprintf("App installation is already at version %s", currentVersion.InternalVersion))
+		return
+	}
+
+	target := selectUpgradeTarget(currentVersion, versions, candidates)
+	if target == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("version"),
+			"Unsupported Upgrade Path",
+			fmt.Sprintf(
+				"There is no supported upgrade path from version %s to any version matching %q. Supported upgrade targets are: %s.",
+				currentVersion.InternalVersion,
+				params.Version.ValueString(),
+				formatVersions(candidates),
+			),
+		)
+		return
+	}
+
+	a.progress(ctx, resp, fmt.Sprintf("Upgrading app installation from version %s to %s", currentVersion.InternalVersion, target.InternalVersion))
+
+	providerutil.
+		Try[any](&resp.Diagnostics, "App Upgrade Error").
+		Do(appClient.UpdateAppinstallation(ctx, appInstallationID, apiext.UpdateAppInstallationVersion(target.Id)))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	a.progress(ctx, resp, "Waiting for the upgrade to complete")
+
+	if err := appClient.WaitUntilAppInstallationIsReady(ctx, appInstallationID); err != nil {
+		resp.Diagnostics.AddError("App Upgrade Error", fmt.Sprintf("The upgrade to version %s was started, but did not complete: %s", target.InternalVersion, err))
+		return
+	}
+
+	a.progress(ctx, resp, fmt.Sprintf("App installation was upgraded to version %s", target.InternalVersion))
+}
+
+func (a *Action) progress(ctx context.Context, resp *action.InvokeResponse, message string) {
+	tflog.Info(ctx, message)
+	resp.SendProgress(action.InvokeProgressEvent{Message: message})
+}
+
+func formatVersions(versions apiext.AppVersionSet) string {
+	if len(versions) == 0 {
+		return "none"
+	}
+
+	names := make([]string, len(versions))
+	for i, version := range versions {
+		names[i] = version.InternalVersion
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,32 @@
+package appupgradeaction
+
+import (
+	"github.com/Masterminds/semver/v3"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+)
+
+// selectUpgradeTarget returns the latest of the given versions that is also an
+// upgrade candidate and newer than the current version, or nil if there is
+// none. The versions must be sorted in ascending order.
+func selectUpgradeTarget(current *appv2.AppVersion, versions, candidates apiext.AppVersionSet) *appv2.AppVersion {
+	currentSemver, err := semver.NewVersion(current.InternalVersion)
+	if err != nil {
+		return nil
+	}
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		version, err := semver.NewVersion(versions[i].InternalVersion)
+		if err != nil || !version.GreaterThan(currentSemver) {
+			continue
+		}
+
+		for _, candidate := range candidates {
+			if candidate.Id == versions[i].Id {
+				return &versions[i]
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package appupgradeaction
+
+import (
+	"testing"
+
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	. "github.com/onsi/gomega"
+)
+
+func TestSelectUpgradeTarget(t *testing.T) {
+	versions := apiext.AppVersionSet{
+		{Id: "1", InternalVersion: "6.4.0"},
+		{Id: "2", InternalVersion: "6.5.0"},
+		{Id: "3", InternalVersion: "6.5.1"},
+		{Id: "4", InternalVersion: "6.6.0"},
+	}
+
+	tests := []struct {
+		name       string
+		current    string
+		selected   []int
+		candidates []int
+		expected   string
+	}{
+		{name: "latest matching candidate", current: "6.4.0", selected: []int{1, 2}, candidates: []int{1, 2, 3}, expected: "3"},
+		{name: "latest matching version is no candidate", current: "6.4.0", selected: []int{1, 2}, candidates: []int{1, 3}, expected: "2"},
+		{name: "no candidates", current: "6.4.0", selected: []int{1, 2}, candidates: nil},
+		{name: "no matching candidate", current: "6.4.0", selected: []int{3}, candidates: []int{1, 2}},
+		{name: "downgrade", current: "6.6.0", selected: []int{0, 1, 2}, candidates: []int{0, 1, 2}},
+		{name: "equal version", current: "6.5.1", selected: []int{1, 2}, candidates: []int{1, 2}},
+		{name: "invalid current version", current: "latest", selected: []int{1, 2}, candidates: []int{1, 2}},
+	}
+
+	subset := func(indices []int) apiext.AppVersionSet {
+		var set apiext.AppVersionSet
+		for _, i := range indices {
+			set = append(set, versions[i])
+		}
+		return set
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			current := &appv2.AppVersion{Id: "current", InternalVersion: test.current}
+			target := selectUpgradeTarget(current, subset(test.selected), subset(test.candidates))
+
+			if test.expected == "" {
+				g.Expect(target).To(BeNil())
+				return
+			}
+
+			g.Expect(target).NotTo(BeNil())
+			g.Expect(target.Id).To(Equal(test.expected))
+		})
+	}
+}
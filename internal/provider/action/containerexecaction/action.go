@@ -0,0 +1,130 @@
+package containerexecaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/alessio/shellescape"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/actionutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
+)
+
+var _ action.Action = &Action{}
+
+// DefaultTimeout is the maximum duration of a command, unless specified
+// otherwise.
+const DefaultTimeout = 10 * time.Minute
+
+type Action struct {
+	client  mittwaldv2.Client
+	sshPool *sshutil.Pool
+}
+
+func New() action.Action {
+	return &Action{}
+}
+
+type ExecModel struct {
+	StackID       types.String `tfsdk:"stack_id"`
+	ContainerID   types.String `tfsdk:"container_id"`
+	Command       types.List   `tfsdk:"command"`
+	Timeout       types.String `tfsdk:"timeout"`
+	SSHUser       types.String `tfsdk:"ssh_user"`
+	SSHPrivateKey types.String `tfsdk:"ssh_private_key"`
+}
+
+func (a *Action) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs a command in a container of a given stack via SSH. The action fails if the command exits with a non-zero exit status.",
+		Attributes: map[string]schema.Attribute{
+			"stack_id": schema.StringAttribute{
+				Description: "ID of the stack that the container belongs to",
+				Required:    true,
+			},
+			"container_id": schema.StringAttribute{
+				Description: "ID of the container in which to run the command",
+				Required:    true,
+			},
+			"command": schema.ListAttribute{
+				Description: "The command to run, as a list of arguments; each argument will be shell-escaped",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Maximum duration of the command, as a Go duration string (like \"30s\" or \"5m\"); defaults to \"" + DefaultTimeout.String() + "\"",
+				Optional:    true,
+			},
+			"ssh_user": schema.StringAttribute{
+				Description: "The SSH username to use for the connection; defaults to the currently authenticated user",
+				Optional:    true,
+			},
+			"ssh_private_key": schema.StringAttribute{
+				Description: "The SSH private key to use for the connection; if not specified, " + sshutil.DefaultAuthDescription,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *Action) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+	a.sshPool = providerutil.SSHPoolFromProviderData(req.ProviderData)
+}
+
+func (a *Action) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_exec"
+}
+
+func (a *Action) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	params := &ExecModel{}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &params)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var args []string
+	resp.Diagnostics.Append(params.Command.ElementsAs(ctx, &args, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(args) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("command"), "Invalid command", "command must contain at least one element")
+		return
+	}
+
+	timeout := actionutil.ParseTimeout(params.Timeout, DefaultTimeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	details, err := apiext.ResolveSSHConnectionDetails(ctx, a.client, apiext.SSHTarget{
+		ContainerID: params.ContainerID.ValueString(),
+		StackID:     params.StackID.ValueString(),
+		User:        params.SSHUser.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Container Exec Error", "Could not determine SSH connection details: "+err.Error())
+		return
+	}
+
+	lease, err := a.sshPool.Acquire(ctx, details.Host, details.User, params.SSHPrivateKey.ValueString(), nil, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError("Container Exec Error", "Could not connect to container via SSH: "+err.Error())
+		return
+	}
+	defer lease.Release()
+
+	actionutil.RunSSHCommand(ctx, lease.Client, shellescape.QuoteCommand(args), nil, "Container Exec Error", resp)
+}
@@ -0,0 +1,101 @@
+package cronjobabortaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/actionutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+)
+
+var _ action.Action = &Action{}
+
+type Action struct {
+	client mittwaldv2.Client
+}
+
+func New() action.Action {
+	return &Action{}
+}
+
+type AbortModel struct {
+	CronjobID   types.String `tfsdk:"cronjob_id"`
+	ExecutionID types.String `tfsdk:"execution_id"`
+}
+
+func (a *Action) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Aborts a running execution of a cronjob. If no execution ID is given, all executions of the cronjob that are currently pending or running are aborted.",
+		Attributes: map[string]schema.Attribute{
+			"cronjob_id": schema.StringAttribute{
+				Description: "ID of the cronjob whose execution should be aborted",
+				Required:    true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+			},
+			"execution_id": schema.StringAttribute{
+				Description: "ID of the execution to abort; defaults to all pending or running executions",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *Action) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (a *Action) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cronjob_abort"
+}
+
+func (a *Action) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	params := &AbortModel{}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &params)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := apiext.NewCronjobClient(a.client)
+	progress := actionutil.NewProgress(resp)
+	cronjobID := params.CronjobID.ValueString()
+
+	executionIDs := []string{params.ExecutionID.ValueString()}
+
+	if params.ExecutionID.IsNull() {
+		executions, err := client.ListAllExecutions(ctx, cronjobID)
+		if err != nil {
+			resp.Diagnostics.AddError("Cronjob Abort Error", err.Error())
+			return
+		}
+
+		executionIDs = nil
+		for _, execution := range executions {
+			if !apiext.CronjobExecutionIsFinished(execution.Status) {
+				executionIDs = append(executionIDs, execution.Id)
+			}
+		}
+
+		if len(executionIDs) == 0 {
+			progress.Send(ctx, "No running executions found")
+			return
+		}
+	}
+
+	for _, executionID := range executionIDs {
+		progress.Send(ctx, fmt.Sprintf("Aborting execution %s", executionID))
+
+		if err := client.AbortRunningExecution(ctx, cronjobID, executionID); err != nil {
+			resp.Diagnostics.AddError("Cronjob Abort Error", err.Error())
+		}
+	}
+}
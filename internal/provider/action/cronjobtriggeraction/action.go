@@ -0,0 +1,120 @@
+package cronjobtriggeraction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/actionutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+)
+
+var _ action.Action = &Action{}
+
+// DefaultTimeout is the maximum duration to wait for an execution to finish,
+// unless specified otherwise.
+const DefaultTimeout = 10 * time.Minute
+
+type Action struct {
+	client mittwaldv2.Client
+}
+
+func New() action.Action {
+	return &Action{}
+}
+
+type TriggerModel struct {
+	CronjobID types.String `tfsdk:"cronjob_id"`
+	Wait      types.Bool   `tfsdk:"wait"`
+	Timeout   types.String `tfsdk:"timeout"`
+}
+
+func (a *Action) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Triggers an immediate execution of a cronjob, independent of its schedule. Optionally waits for the execution to finish; in that case, the action fails if the execution does not complete successfully.",
+		Attributes: map[string]schema.Attribute{
+			"cronjob_id": schema.StringAttribute{
+				Description: "ID of the cronjob to execute",
+				Required:    true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Whether to wait for the execution to finish, and fail if it does not complete successfully; defaults to false",
+				Optional:    true,
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Maximum duration to wait for the execution to finish, as a Go duration string (like \"30s\" or \"5m\"); only applies when wait is set. Defaults to \"" + DefaultTimeout.String() + "\"",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *Action) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (a *Action) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cronjob_trigger"
+}
+
+func (a *Action) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	params := &TriggerModel{}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &params)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := actionutil.ParseTimeout(params.Timeout, DefaultTimeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := apiext.NewCronjobClient(a.client)
+	progress := actionutil.NewProgress(resp)
+	cronjobID := params.CronjobID.ValueString()
+
+	progress.Send(ctx, "Triggering cronjob execution")
+
+	executionID, err := client.TriggerExecution(ctx, cronjobID)
+	if err != nil {
+		resp.Diagnostics.AddError("Cronjob Trigger Error", err.Error())
+		return
+	}
+
+	if !params.Wait.ValueBool() {
+		progress.Send(ctx, fmt.Sprintf("Triggered execution %s", executionID))
+		return
+	}
+
+	progress.Send(ctx, fmt.Sprintf("Waiting for execution %s to finish", executionID))
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	execution, err := client.WaitUntilExecutionIsFinished(waitCtx, cronjobID, executionID)
+	if err != nil {
+		resp.Diagnostics.AddError("Cronjob Trigger Error", fmt.Sprintf("Execution %s did not finish: %s", executionID, err))
+		return
+	}
+
+	if !apiext.CronjobExecutionIsSuccessful(execution.Status) {
+		resp.Diagnostics.AddError(
+			"Cronjob Trigger Error",
+			fmt.Sprintf("Execution %s did not complete successfully (status: %s). Use the mittwald_cronjob_executions data source to inspect its log.", executionID, execution.Status),
+		)
+		return
+	}
+
+	progress.Send(ctx, fmt.Sprintf("Execution %s completed successfully", executionID))
+}
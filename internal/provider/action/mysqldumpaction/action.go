@@ -0,0 +1,151 @@
+package mysqldumpaction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/actionutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
+)
+
+var _ action.Action = &Action{}
+
+// DefaultTimeout is the maximum duration of a dump, unless specified
+// otherwise.
+const DefaultTimeout = 1 * time.Hour
+
+type Action struct {
+	client  mittwaldv2.Client
+	sshPool *sshutil.Pool
+}
+
+func New() action.Action {
+	return &Action{}
+}
+
+type DumpModel struct {
+	DatabaseID    types.String `tfsdk:"database_id"`
+	Destination   types.String `tfsdk:"destination"`
+	User          types.String `tfsdk:"user"`
+	Password      types.String `tfsdk:"password"`
+	Timeout       types.String `tfsdk:"timeout"`
+	SSHUser       types.String `tfsdk:"ssh_user"`
+	SSHPrivateKey types.String `tfsdk:"ssh_private_key"`
+}
+
+func (a *Action) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Dumps a MySQL database into a local file, using mysqldump via an SSH connection to the project of the database. The SHA-256 checksum of the dump is written to a file next to it (with an additional \".sha256\" extension, in the format of sha256sum); an existing dump at the destination is only replaced once the new dump is complete. The mittwald_mysql_dump data source verifies a dump against its checksum file, and exposes its checksum and size.",
+		Attributes: map[string]schema.Attribute{
+			"database_id": schema.StringAttribute{
+				Description: "ID of the MySQL database to dump",
+				Required:    true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+			},
+			"destination": schema.StringAttribute{
+				Description: "Local path to write the dump to; if the path ends with \".gz\", the dump is gzip-compressed",
+				Required:    true,
+			},
+			"user": schema.StringAttribute{
+				Description: "Name of the MySQL user to dump the database with",
+				Required:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "Password of the MySQL user",
+				Required:    true,
+				WriteOnly:   true,
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Maximum duration of the dump, as a Go duration string (like \"30s\" or \"5m\"); defaults to \"" + DefaultTimeout.String() + "\"",
+				Optional:    true,
+			},
+			"ssh_user": schema.StringAttribute{
+				Description: "The SSH username to use for the connection; defaults to the currently authenticated user",
+				Optional:    true,
+			},
+			"ssh_private_key": schema.StringAttribute{
+				Description: "The SSH private key to use for the connection; if not specified, " + sshutil.DefaultAuthDescription,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *Action) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+	a.sshPool = providerutil.SSHPoolFromProviderData(req.ProviderData)
+}
+
+func (a *Action) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mysql_dump"
+}
+
+func (a *Action) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	params := &DumpModel{}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &params)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := actionutil.ParseTimeout(params.Timeout, DefaultTimeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn := actionutil.ConnectMySQL(ctx, a.client, a.sshPool, actionutil.MySQLConnectionParams{
+		DatabaseID:    params.DatabaseID.ValueString(),
+		User:          params.User.ValueString(),
+		Password:      params.Password.ValueString(),
+		SSHUser:       params.SSHUser.ValueString(),
+		SSHPrivateKey: params.SSHPrivateKey.ValueString(),
+	}, "MySQL Dump Error", &resp.Diagnostics)
+	if conn == nil {
+		return
+	}
+	defer conn.Close(ctx, &resp.Diagnostics)
+
+	progress := actionutil.NewProgress(resp)
+
+	dump, err := createDumpFile(params.Destination.ValueString(), func(written int64) {
+		progress.Send(ctx, fmt.Sprintf("Dumped %s", actionutil.FormatBytes(written)))
+	})
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("destination"), "MySQL Dump Error", err.Error())
+		return
+	}
+	defer dump.Abort()
+
+	progress.Send(ctx, fmt.Sprintf("Dumping database %s to %s", conn.Database.Name, params.Destination.ValueString()))
+
+	// --single-transaction creates a consistent dump of InnoDB tables without
+	// locking them; --no-tablespaces avoids requiring the PROCESS privilege.
+	command := conn.Command("mysqldump", "--single-transaction", "--quick", "--routines", "--triggers", "--no-tablespaces", conn.Database.Name)
+
+	actionutil.StreamSSHCommand(ctx, conn.Client(), command, dump, "MySQL Dump Error", resp, progress)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checksum, err := dump.Commit()
+	if err != nil {
+		resp.Diagnostics.AddError("MySQL Dump Error", err.Error())
+		return
+	}
+
+	progress.Send(ctx, fmt.Sprintf("Database %s was dumped to %s (%s, SHA-256 %s)", conn.Database.Name, params.Destination.ValueString(), actionutil.FormatBytes(dump.written), checksum))
+}
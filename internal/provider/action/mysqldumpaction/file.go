@@ -0,0 +1,110 @@
+package mysqldumpaction
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mittwald/terraform-provider-mittwald/internal/dumputil"
+)
+
+// progressInterval is the number of bytes after which the progress of a dump
+// is reported.
+const progressInterval = 16 * 1024 * 1024
+
+// dumpFile is a local file that a dump is written to. The dump is written to
+// a temporary file first, which is only moved to the destination on Commit, so
+// that an incomplete dump never ends up at the destination.
+type dumpFile struct {
+	destination string
+	tmp         *os.File
+	gzip        *gzip.Writer
+	hash        hash.Hash
+	writer      io.Writer
+
+	written    int64
+	onProgress func(written int64)
+}
+
+func createDumpFile(destination string, onProgress func(written int64)) (*dumpFile, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(destination), "."+filepath.Base(destination)+".tmp-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dump file: %w", err)
+	}
+
+	d := &dumpFile{
+		destination: destination,
+		tmp:         tmp,
+		hash:        sha256.New(),
+		onProgress:  onProgress,
+	}
+
+	// The checksum is computed over the file as it is written to disk.
+	out := io.MultiWriter(tmp, d.hash)
+
+	if strings.HasSuffix(destination, ".gz") {
+		d.gzip = gzip.NewWriter(out)
+		d.writer = d.gzip
+	} else {
+		d.writer = out
+	}
+
+	return d, nil
+}
+
+func (d *dumpFile) Write(b []byte) (int, error) {
+	n, err := d.writer.Write(b)
+
+	before := d.written
+	d.written += int64(n)
+
+	if d.onProgress != nil && d.written/progressInterval > before/progressInterval {
+		d.onProgress(d.written)
+	}
+
+	return n, err
+}
+
+// Commit moves the dump to its destination and writes its SHA-256 checksum to
+// a file next to it (see dumputil.WriteChecksumFile); it returns the checksum.
+//
+// Both files are written to temporary files first and then renamed. The
+// checksum file is moved into place before the dump, so that a dump at the
+// destination is never accompanied by a missing or incomplete checksum file;
+// at worst, the previous dump is briefly accompanied by the new checksum, in
+// which case verification fails instead of silently succeeding.
+func (d *dumpFile) Commit() (string, error) {
+	if d.gzip != nil {
+		if err := d.gzip.Close(); err != nil {
+			return "", fmt.Errorf("failed to write dump file: %w", err)
+		}
+	}
+
+	if err := d.tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write dump file: %w", err)
+	}
+
+	checksum := hex.EncodeToString(d.hash.Sum(nil))
+
+	if err := dumputil.WriteChecksumFile(d.destination, checksum); err != nil {
+		return "", fmt.Errorf("failed to write checksum file: %w", err)
+	}
+
+	if err := os.Rename(d.tmp.Name(), d.destination); err != nil {
+		return "", fmt.Errorf("failed to move dump file to %s: %w", d.destination, err)
+	}
+
+	return checksum, nil
+}
+
+// Abort removes the temporary file; it is a no-op after Commit.
+func (d *dumpFile) Abort() {
+	_ = d.tmp.Close()
+	_ = os.Remove(d.tmp.Name())
+}
@@ -0,0 +1,72 @@
+package mysqldumpaction
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mittwald/terraform-provider-mittwald/internal/dumputil"
+	. "github.com/onsi/gomega"
+)
+
+func TestDumpFileCommit(t *testing.T) {
+	for _, name := range []string{"dump.sql", "dump.sql.gz"} {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			destination := filepath.Join(t.TempDir(), name)
+			g.Expect(os.WriteFile(destination, []byte("previous dump"), 0o600)).To(Succeed())
+
+			dump, err := createDumpFile(destination, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+			defer dump.Abort()
+
+			_, err = dump.Write([]byte("CREATE TABLE t (id INT);\n"))
+			g.Expect(err).NotTo(HaveOccurred())
+
+			// The previous dump is retained until the new one is complete.
+			previous, err := os.ReadFile(destination)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(previous)).To(Equal("previous dump"))
+
+			checksum, err := dump.Commit()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			contents, err := os.ReadFile(destination)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			sum := sha256.Sum256(contents)
+			g.Expect(checksum).To(Equal(hex.EncodeToString(sum[:])))
+
+			checksumFile, err := os.ReadFile(destination + dumputil.ChecksumFileSuffix)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(checksumFile)).To(Equal(checksum + "  " + name + "\n"))
+
+			if filepath.Ext(name) == ".gz" {
+				r, err := gzip.NewReader(bytes.NewReader(contents))
+				g.Expect(err).NotTo(HaveOccurred())
+				contents, err = io.ReadAll(r)
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			g.Expect(string(contents)).To(Equal("CREATE TABLE t (id INT);\n"))
+
+			entries, err := os.ReadDir(filepath.Dir(destination))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(entries).To(HaveLen(2))
+
+			verified, size, err := dumputil.VerifyDumpFile(destination)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(verified).To(Equal(checksum))
+
+			info, err := os.Stat(destination)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(size).To(Equal(info.Size()))
+		})
+	}
+}
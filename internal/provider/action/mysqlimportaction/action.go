@@ -0,0 +1,148 @@
+package mysqlimportaction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/actionutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
+)
+
+var _ action.Action = &Action{}
+
+// DefaultTimeout is the maximum duration of an import, unless specified
+// otherwise.
+const DefaultTimeout = 1 * time.Hour
+
+type Action struct {
+	client  mittwaldv2.Client
+	sshPool *sshutil.Pool
+}
+
+func New() action.Action {
+	return &Action{}
+}
+
+type ImportModel struct {
+	DatabaseID    types.String `tfsdk:"database_id"`
+	Source        types.String `tfsdk:"source"`
+	User          types.String `tfsdk:"user"`
+	Password      types.String `tfsdk:"password"`
+	Timeout       types.String `tfsdk:"timeout"`
+	SSHUser       types.String `tfsdk:"ssh_user"`
+	SSHPrivateKey types.String `tfsdk:"ssh_private_key"`
+}
+
+func (a *Action) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Imports a local SQL dump into a MySQL database. The dump is streamed via an SSH connection to the project of the database into the mysql client; the action fails on the first SQL error. Note that the import is not transactional, so the statements before a failing statement remain applied.",
+		Attributes: map[string]schema.Attribute{
+			"database_id": schema.StringAttribute{
+				Description: "ID of the MySQL database to import the dump into",
+				Required:    true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+			},
+			"source": schema.StringAttribute{
+				Description: "Path of the local SQL dump file; gzip-compressed dumps are decompressed automatically",
+				Required:    true,
+			},
+			"user": schema.StringAttribute{
+				Description: "Name of the MySQL user to import the dump with; the user needs write access to the database",
+				Required:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "Password of the MySQL user",
+				Required:    true,
+				WriteOnly:   true,
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Maximum duration of the import, as a Go duration string (like \"30s\" or \"5m\"); defaults to \"" + DefaultTimeout.String() + "\"",
+				Optional:    true,
+			},
+			"ssh_user": schema.StringAttribute{
+				Description: "The SSH username to use for the connection; defaults to the currently authenticated user",
+				Optional:    true,
+			},
+			"ssh_private_key": schema.StringAttribute{
+				Description: "The SSH private key to use for the connection; if not specified, " + sshutil.DefaultAuthDescription,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *Action) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+	a.sshPool = providerutil.SSHPoolFromProviderData(req.ProviderData)
+}
+
+func (a *Action) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mysql_import"
+}
+
+func (a *Action) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	params := &ImportModel{}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &params)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := actionutil.ParseTimeout(params.Timeout, DefaultTimeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	progress := actionutil.NewProgress(resp)
+
+	dump, err := openDumpFile(params.Source.ValueString(), func(read, total int64) {
+		progress.Send(ctx, fmt.Sprintf("Imported %d%% of the dump file (%s of %s)", read*100/total, actionutil.FormatBytes(read), actionutil.FormatBytes(total)))
+	})
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source"), "MySQL Import Error", err.Error())
+		return
+	}
+	defer func() { _ = dump.Close() }()
+
+	conn := actionutil.ConnectMySQL(ctx, a.client, a.sshPool, actionutil.MySQLConnectionParams{
+		DatabaseID:    params.DatabaseID.ValueString(),
+		User:          params.User.ValueString(),
+		Password:      params.Password.ValueString(),
+		SSHUser:       params.SSHUser.ValueString(),
+		SSHPrivateKey: params.SSHPrivateKey.ValueString(),
+	}, "MySQL Import Error", &resp.Diagnostics)
+	if conn == nil {
+		return
+	}
+	defer conn.Close(ctx, &resp.Diagnostics)
+
+	compression := ""
+	if dump.compressed {
+		compression = "gzip-compressed "
+	}
+
+	progress.Send(ctx, fmt.Sprintf("Importing %sdump (%s) into database %s", compression, actionutil.FormatBytes(dump.size), conn.Database.Name))
+
+	command := conn.Command("mysql", "--batch", conn.Database.Name)
+
+	actionutil.RunSSHCommandWithProgress(ctx, conn.Client(), command, dump, "MySQL Import Error", resp, progress)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	progress.Send(ctx, fmt.Sprintf("Dump was imported into database %s", conn.Database.Name))
+}
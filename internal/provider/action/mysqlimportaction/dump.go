@@ -0,0 +1,95 @@
+package mysqlimportaction
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// progressSteps is the number of progress events that are reported while
+// reading a dump file.
+const progressSteps = 10
+
+// dumpFile is a local SQL dump that is read for the import; gzip-compressed
+// dumps are decompressed transparently.
+type dumpFile struct {
+	io.Reader
+
+	file       *os.File
+	size       int64
+	compressed bool
+}
+
+// openDumpFile opens a dump file. While the dump is read, onProgress is
+// called in steps of ten percent with the number of bytes read from the file
+// on disk (the size of a compressed dump after decompression is not known in
+// advance).
+func openDumpFile(name string, onProgress func(read, total int64)) (*dumpFile, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dump file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to stat dump file: %w", err)
+	}
+
+	if !info.Mode().IsRegular() {
+		_ = file.Close()
+		return nil, fmt.Errorf("%s is not a regular file", name)
+	}
+
+	d := &dumpFile{file: file, size: info.Size()}
+
+	buffered := bufio.NewReader(&progressReader{r: file, total: d.size, onProgress: onProgress})
+
+	if magic, err := buffered.Peek(len(gzipMagic)); err == nil && bytes.Equal(magic, gzipMagic) {
+		decompressed, err := gzip.NewReader(buffered)
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to read gzip-compressed dump file: %w", err)
+		}
+
+		d.Reader = decompressed
+		d.compressed = true
+	} else {
+		d.Reader = buffered
+	}
+
+	return d, nil
+}
+
+func (d *dumpFile) Close() error {
+	return d.file.Close()
+}
+
+// progressReader calls onProgress whenever another step (see progressSteps)
+// of the expected total has been read.
+type progressReader struct {
+	r          io.Reader
+	read       int64
+	total      int64
+	step       int64
+	onProgress func(read, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+
+	if p.onProgress != nil && p.total > 0 {
+		if step := p.read * progressSteps / p.total; step > p.step {
+			p.step = step
+			p.onProgress(p.read, p.total)
+		}
+	}
+
+	return n, err
+}
@@ -0,0 +1,49 @@
+package mysqlimportaction
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestOpenDumpFile(t *testing.T) {
+	sql := strings.Repeat("INSERT INTO t VALUES (1);\n", 1000)
+
+	compressed := bytes.Buffer{}
+	w := gzip.NewWriter(&compressed)
+	_, _ = w.Write([]byte(sql))
+	_ = w.Close()
+
+	for name, contents := range map[string][]byte{"dump.sql": []byte(sql), "dump.sql.gz": compressed.Bytes()} {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			file := filepath.Join(t.TempDir(), name)
+			g.Expect(os.WriteFile(file, contents, 0o644)).To(Succeed())
+
+			var reported []int64
+
+			dump, err := openDumpFile(file, func(read, total int64) {
+				g.Expect(total).To(BeEquivalentTo(len(contents)))
+				reported = append(reported, read)
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = dump.Close() }()
+
+			g.Expect(dump.compressed).To(Equal(strings.HasSuffix(name, ".gz")))
+
+			read, err := io.ReadAll(dump)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(read)).To(Equal(sql))
+
+			g.Expect(reported).NotTo(BeEmpty())
+			g.Expect(reported[len(reported)-1]).To(BeEquivalentTo(len(contents)))
+		})
+	}
+}
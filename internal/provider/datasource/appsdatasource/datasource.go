@@ -0,0 +1,251 @@
+package appsdatasource
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/appclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiutils"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+	"github.com/mittwald/terraform-provider-mittwald/internal/valueutil"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DataSource{}
+
+func New() datasource.DataSource {
+	return &DataSource{}
+}
+
+// DataSource defines the data source implementation.
+type DataSource struct {
+	client mittwaldv2.Client
+}
+
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_apps"
+}
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A data source that lists the app installations in a project.\n\n" +
+			"This is useful for referencing app installations that are not managed in the same Terraform configuration, " +
+			"for example when configuring ingresses or cronjobs for them. Use the `app` and `description` attributes to " +
+			"narrow down the list of installations.",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project whose app installations should be listed. Must be a full UUID (not a short ID like p-XXXXXX).",
+				Required:            true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+			},
+			"app": schema.StringAttribute{
+				MarkdownDescription: "Only list installations of the app with this name (ignoring case), like `wordpress` or `node`",
+				Optional:            true,
+				Validators: []validator.String{
+					&appNameValidator{},
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Only list installations whose description matches this pattern; supports shell-style wildcards like `Marketing *`",
+				Optional:            true,
+				Validators: []validator.String{
+					&patternValidator{},
+				},
+			},
+			"apps": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching app installations",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the app installation",
+							Computed:            true,
+						},
+						"short_id": schema.StringAttribute{
+							MarkdownDescription: "The short ID of the app installation",
+							Computed:            true,
+						},
+						"app": schema.StringAttribute{
+							MarkdownDescription: "The name of the app; this is null for apps that are not supported by the `mittwald_app` resource",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the app installation",
+							Computed:            true,
+						},
+						"version_current": schema.StringAttribute{
+							MarkdownDescription: "The currently installed version of the app",
+							Computed:            true,
+						},
+						"installation_path": schema.StringAttribute{
+							MarkdownDescription: "The installation path of the app, relative to the web root",
+							Computed:            true,
+						},
+						"document_root": schema.StringAttribute{
+							MarkdownDescription: "The custom document root of the app, if any",
+							Computed:            true,
+						},
+						"databases": schema.ListNestedAttribute{
+							MarkdownDescription: "The databases that are linked to the app installation",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										MarkdownDescription: "The ID of the database",
+										Computed:            true,
+									},
+									"kind": schema.StringAttribute{
+										MarkdownDescription: "The kind of the database; one of `mysql` or `redis`",
+										Computed:            true,
+									},
+									"purpose": schema.StringAttribute{
+										MarkdownDescription: "The purpose of the database, like `primary` or `cache`",
+										Computed:            true,
+									},
+									"user_id": schema.StringAttribute{
+										MarkdownDescription: "The ID of the database user that the app uses",
+										Computed:            true,
+									},
+								},
+							},
+						},
+						"dependencies": schema.MapNestedAttribute{
+							MarkdownDescription: "The system software (like PHP or Node.js) that is installed for the app, keyed by name",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"version": schema.StringAttribute{
+										MarkdownDescription: "The version of the dependency",
+										Computed:            true,
+									},
+									"update_policy": schema.StringAttribute{
+										MarkdownDescription: "The update policy of the dependency",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appClient := apiext.NewAppClient(d.client)
+
+	installations, err := apiutils.FetchAllPages(ctx, 100, func(ctx context.Context, limit, page int64) (*[]appv2.AppInstallation, *http.Response, error) {
+		return appClient.ListAppinstallations(ctx, appclientv2.ListAppinstallationsRequest{
+			ProjectID: data.ProjectID.ValueString(),
+			Limit:     &limit,
+			Page:      &page,
+		})
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list app installations", err.Error())
+		return
+	}
+
+	filter := appFilter{
+		app:         data.App.ValueString(),
+		description: data.Description.ValueString(),
+	}
+
+	resolver := newVersionResolver(appClient)
+
+	data.Apps = make([]AppModel, 0)
+	for _, installation := range filter.apply(installations) {
+		data.Apps = append(data.Apps, d.appModel(ctx, installation, resolver, &resp.Diagnostics))
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *DataSource) appModel(ctx context.Context, installation appv2.AppInstallation, resolver *versionResolver, diags *diag.Diagnostics) AppModel {
+	model := AppModel{
+		ID:               types.StringValue(installation.Id),
+		ShortID:          types.StringValue(installation.ShortId),
+		App:              types.StringNull(),
+		Description:      valueutil.StringOrNull(installation.Description),
+		VersionCurrent:   types.StringNull(),
+		InstallationPath: types.StringValue(installation.InstallationPath),
+		DocumentRoot:     valueutil.StringPtrOrNull(installation.CustomDocumentRoot),
+		Databases:        make([]DatabaseModel, 0, len(installation.LinkedDatabases)),
+		Dependencies:     make(map[string]DependencyModel, len(installation.SystemSoftware)),
+	}
+
+	if name, ok := appName(installation.AppId); ok {
+		model.App = types.StringValue(name)
+	}
+
+	if installation.AppVersion.Current != nil {
+		version, err := resolver.appVersion(ctx, installation.AppId, *installation.AppVersion.Current)
+		if err != nil {
+			diags.AddError("Failed to get app version", fmt.Sprintf("error while fetching the version of app installation %s: %s", installation.Id, err))
+		} else {
+			model.VersionCurrent = types.StringValue(version)
+		}
+	}
+
+	for _, db := range installation.LinkedDatabases {
+		database := DatabaseModel{
+			ID:      types.StringValue(db.DatabaseId),
+			Kind:    types.StringValue(string(db.Kind)),
+			Purpose: types.StringValue(string(db.Purpose)),
+			UserID:  types.StringNull(),
+		}
+
+		if userID, ok := db.DatabaseUserIds["admin"]; ok {
+			database.UserID = types.StringValue(userID)
+		}
+
+		model.Databases = append(model.Databases, database)
+	}
+
+	for _, software := range installation.SystemSoftware {
+		name, version, err := resolver.systemSoftwareVersion(ctx, software.SystemSoftwareId, software.SystemSoftwareVersion.Desired)
+		if err != nil {
+			diags.AddError("Failed to get system software", fmt.Sprintf("error while fetching the dependencies of app installation %s: %s", installation.Id, err))
+			continue
+		}
+
+		model.Dependencies[name] = DependencyModel{
+			Version:      types.StringValue(version),
+			UpdatePolicy: types.StringValue(string(software.UpdatePolicy)),
+		}
+	}
+
+	return model
+}
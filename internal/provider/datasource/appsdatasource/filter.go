@@ -0,0 +1,59 @@
+package appsdatasource
+
+import (
+	"path"
+	"strings"
+
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+)
+
+// appFilter selects app installations by app name (ignoring case) and
+// description; empty fields match all installations. The fields are expected
+// to be valid (see appNameValidator and patternValidator).
+type appFilter struct {
+	app         string
+	description string
+}
+
+// apply returns the installations that match the filter.
+func (f appFilter) apply(installations []appv2.AppInstallation) []appv2.AppInstallation {
+	matches := make([]appv2.AppInstallation, 0, len(installations))
+
+	for _, installation := range installations {
+		if f.matches(installation) {
+			matches = append(matches, installation)
+		}
+	}
+
+	return matches
+}
+
+func (f appFilter) matches(installation appv2.AppInstallation) bool {
+	if f.app != "" {
+		if appID, ok := apiext.AppNames[strings.ToLower(f.app)]; !ok || appID != installation.AppId {
+			return false
+		}
+	}
+
+	if f.description != "" {
+		matched, err := path.Match(f.description, installation.Description)
+		if err != nil || !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// appName returns the name of the app with the given ID, as used in the `app`
+// attribute of the `mittwald_app` resource.
+func appName(appID string) (string, bool) {
+	for name, id := range apiext.AppNames {
+		if id == appID {
+			return name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,84 @@
+package appsdatasource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	. "github.com/onsi/gomega"
+)
+
+func TestAppFilter(t *testing.T) {
+	installations := []appv2.AppInstallation{
+		{Id: "1", AppId: apiext.AppNames["wordpress"], Description: "Marketing Blog"},
+		{Id: "2", AppId: apiext.AppNames["wordpress"], Description: "Support Blog"},
+		{Id: "3", AppId: apiext.AppNames["node"], Description: "Marketing API"},
+	}
+
+	ids := func(installations []appv2.AppInstallation) []string {
+		result := make([]string, len(installations))
+		for i, installation := range installations {
+			result[i] = installation.Id
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		filter   appFilter
+		expected []string
+	}{
+		{"no filter", appFilter{}, []string{"1", "2", "3"}},
+		{"by app", appFilter{app: "wordpress"}, []string{"1", "2"}},
+		{"by app ignoring case", appFilter{app: "WordPress"}, []string{"1", "2"}},
+		{"by description", appFilter{description: "Marketing API"}, []string{"3"}},
+		{"by description pattern", appFilter{description: "Marketing *"}, []string{"1", "3"}},
+		{"by app and description", appFilter{app: "wordpress", description: "Marketing *"}, []string{"1"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ids(test.filter.apply(installations))).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestFilterValidators(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		validator   validator.String
+		value       types.String
+		expectError bool
+	}{
+		{name: "known app", validator: &appNameValidator{}, value: types.StringValue("wordpress")},
+		{name: "known app ignoring case", validator: &appNameValidator{}, value: types.StringValue("WordPress")},
+		{name: "unknown app", validator: &appNameValidator{}, value: types.StringValue("unknown"), expectError: true},
+		{name: "null app", validator: &appNameValidator{}, value: types.StringNull()},
+		{name: "pattern", validator: &patternValidator{}, value: types.StringValue("Marketing *")},
+		{name: "invalid pattern", validator: &patternValidator{}, value: types.StringValue("Marketing ["), expectError: true},
+		{name: "unknown pattern", validator: &patternValidator{}, value: types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			req := validator.StringRequest{
+				Path:        path.Root("test_value"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.StringResponse{}
+
+			tt.validator.ValidateString(ctx, req, resp)
+
+			g.Expect(resp.Diagnostics.HasError()).To(Equal(tt.expectError))
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package appsdatasource
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// DataSourceModel describes the data source data model.
+type DataSourceModel struct {
+	ProjectID   types.String `tfsdk:"project_id"`
+	App         types.String `tfsdk:"app"`
+	Description types.String `tfsdk:"description"`
+
+	Apps []AppModel `tfsdk:"apps"`
+}
+
+type AppModel struct {
+	ID               types.String               `tfsdk:"id"`
+	ShortID          types.String               `tfsdk:"short_id"`
+	App              types.String               `tfsdk:"app"`
+	Description      types.String               `tfsdk:"description"`
+	VersionCurrent   types.String               `tfsdk:"version_current"`
+	InstallationPath types.String               `tfsdk:"installation_path"`
+	DocumentRoot     types.String               `tfsdk:"document_root"`
+	Databases        []DatabaseModel            `tfsdk:"databases"`
+	Dependencies     map[string]DependencyModel `tfsdk:"dependencies"`
+}
+
+type DatabaseModel struct {
+	ID      types.String `tfsdk:"id"`
+	Kind    types.String `tfsdk:"kind"`
+	Purpose types.String `tfsdk:"purpose"`
+	UserID  types.String `tfsdk:"user_id"`
+}
+
+type DependencyModel struct {
+	Version      types.String `tfsdk:"version"`
+	UpdatePolicy types.String `tfsdk:"update_policy"`
+}
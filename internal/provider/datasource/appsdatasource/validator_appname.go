@@ -0,0 +1,48 @@
+package appsdatasource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+)
+
+var _ validator.String = &appNameValidator{}
+
+// appNameValidator validates that the value is the name of a known app (see
+// apiext.AppNames), ignoring case.
+type appNameValidator struct{}
+
+func (v *appNameValidator) Description(_ context.Context) string {
+	return "Validates that the value is the name of a known app, like wordpress or node."
+}
+
+func (v *appNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *appNameValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, ok := apiext.AppNames[strings.ToLower(request.ConfigValue.ValueString())]; ok {
+		return
+	}
+
+	names := make([]string, 0, len(apiext.AppNames))
+	for name := range apiext.AppNames {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	response.Diagnostics.AddAttributeError(
+		request.Path,
+		"Unknown app",
+		fmt.Sprintf("%q is not a known app; must be one of: %s", request.ConfigValue.ValueString(), strings.Join(names, ", ")),
+	)
+}
@@ -0,0 +1,32 @@
+package appsdatasource
+
+import (
+	"context"
+	"path"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = &patternValidator{}
+
+// patternValidator validates that the value is a valid shell-style pattern,
+// as supported by path.Match.
+type patternValidator struct{}
+
+func (v *patternValidator) Description(_ context.Context) string {
+	return "Validates that the value is a valid shell-style pattern, like `Marketing *`."
+}
+
+func (v *patternValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *patternValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := path.Match(request.ConfigValue.ValueString(), ""); err != nil {
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid pattern", err.Error())
+	}
+}
@@ -0,0 +1,60 @@
+package appsdatasource
+
+import (
+	"context"
+
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/appclientv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+)
+
+// versionResolver resolves app and system software version IDs into their
+// names; since many installations in a project usually share the same
+// versions, results are cached.
+type versionResolver struct {
+	client apiext.AppClient
+
+	appVersions            map[string]string
+	systemSoftwareVersions map[string]systemSoftwareVersion
+}
+
+type systemSoftwareVersion struct {
+	name    string
+	version string
+}
+
+func newVersionResolver(client apiext.AppClient) *versionResolver {
+	return &versionResolver{
+		client:                 client,
+		appVersions:            make(map[string]string),
+		systemSoftwareVersions: make(map[string]systemSoftwareVersion),
+	}
+}
+
+func (r *versionResolver) appVersion(ctx context.Context, appID, appVersionID string) (string, error) {
+	if version, ok := r.appVersions[appVersionID]; ok {
+		return version, nil
+	}
+
+	version, _, err := r.client.GetAppversion(ctx, appclientv2.GetAppversionRequest{AppID: appID, AppVersionID: appVersionID})
+	if err != nil {
+		return "", err
+	}
+
+	r.appVersions[appVersionID] = version.InternalVersion
+	return version.InternalVersion, nil
+}
+
+// systemSoftwareVersion returns the name of the system software and its version.
+func (r *versionResolver) systemSoftwareVersion(ctx context.Context, systemSoftwareID, systemSoftwareVersionID string) (string, string, error) {
+	if cached, ok := r.systemSoftwareVersions[systemSoftwareVersionID]; ok {
+		return cached.name, cached.version, nil
+	}
+
+	software, version, err := r.client.GetSystemsoftwareAndVersion(ctx, systemSoftwareID, systemSoftwareVersionID)
+	if err != nil {
+		return "", "", err
+	}
+
+	r.systemSoftwareVersions[systemSoftwareVersionID] = systemSoftwareVersion{name: software.Name, version: version.InternalVersion}
+	return software.Name, version.InternalVersion, nil
+}
@@ -0,0 +1,97 @@
+package containerlogsdatasource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DataSource{}
+
+func New() datasource.DataSource {
+	return &DataSource{}
+}
+
+// DataSource defines the data source implementation.
+type DataSource struct {
+	client mittwaldv2.Client
+}
+
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_logs"
+}
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A data source that retrieves the most recent log output of a container.\n\n" +
+			"This is mostly useful for inspecting a container that does not behave as expected, for example " +
+			"in an `output` or a `check` block. Note that the logs are read anew on every plan, so you should " +
+			"not use them as input for other resources.",
+
+		Attributes: map[string]schema.Attribute{
+			"stack_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the stack that the container belongs to.",
+				Required:            true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+			},
+			"container_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the container whose logs should be retrieved. Must be a full UUID (not a short ID like c-XXXXXX).",
+				Required:            true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+			},
+			"tail": schema.Int64Attribute{
+				MarkdownDescription: "The number of log lines to retrieve, counting from the end of the log; defaults to `100`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					&common.Int64AtLeastValidator{Min: 1},
+				},
+			},
+			"logs": schema.StringAttribute{
+				MarkdownDescription: "The most recent log output of the container.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := apiext.NewContainerClient(d.client)
+
+	logs := providerutil.
+		Try[string](&resp.Diagnostics, "error while fetching container logs").
+		DoVal(client.GetServiceLogTail(ctx, data.StackID.ValueString(), data.ContainerID.ValueString(), data.TailOrDefault()))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Logs = types.StringValue(logs)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,19 @@
+package containerlogsdatasource
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// DataSourceModel describes the data source data model.
+type DataSourceModel struct {
+	StackID     types.String `tfsdk:"stack_id"`
+	ContainerID types.String `tfsdk:"container_id"`
+	Tail        types.Int64  `tfsdk:"tail"`
+
+	Logs types.String `tfsdk:"logs"`
+}
+
+func (m *DataSourceModel) TailOrDefault() int64 {
+	if m.Tail.IsNull() {
+		return 100
+	}
+	return m.Tail.ValueInt64()
+}
@@ -0,0 +1,202 @@
+package cronjobexecutionsdatasource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/cronjobclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/cronjobv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DataSource{}
+
+func New() datasource.DataSource {
+	return &DataSource{}
+}
+
+// DataSource defines the data source implementation.
+type DataSource struct {
+	client  mittwaldv2.Client
+	sshPool *sshutil.Pool
+}
+
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cronjob_executions"
+}
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A data source that lists the most recent executions of a cronjob.\n\n" +
+			"This is mostly useful in `check` blocks, to get notified when a critical cronjob has been failing. " +
+			"Note that the executions are read anew on every plan, so you should not use them as input for other " +
+			"resources.",
+
+		Attributes: map[string]schema.Attribute{
+			"cronjob_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cronjob whose executions should be listed. Must be a full UUID.",
+				Required:            true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of executions to list; defaults to `10`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					&common.Int64AtLeastValidator{Min: 1},
+				},
+			},
+			"log_lines": schema.Int64Attribute{
+				MarkdownDescription: "The number of log lines to retrieve for each execution, counting from the end of the log. " +
+					"Logs are read via SSH, so they are only retrieved when this is set.",
+				Optional: true,
+				Validators: []validator.Int64{
+					&common.Int64AtLeastValidator{Min: 0},
+				},
+			},
+			"ssh_user": schema.StringAttribute{
+				MarkdownDescription: "The SSH username to use for reading logs; defaults to the currently authenticated user.",
+				Optional:            true,
+			},
+			"ssh_private_key": schema.StringAttribute{
+				MarkdownDescription: "The SSH private key to use for reading logs. If not specified, " + sshutil.DefaultAuthDescription + ".",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"executions": schema.ListNestedAttribute{
+				MarkdownDescription: "The most recent executions of the cronjob, most recent first",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the execution",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The status of the execution, like `Running`, `Complete` or `Failed`. The API does not expose the exit code of the command; for failed executions, see `log` for details.",
+							Computed:            true,
+						},
+						"successful": schema.BoolAttribute{
+							MarkdownDescription: "Whether the execution has completed successfully; this is false for executions that are still running",
+							Computed:            true,
+						},
+						"start": schema.StringAttribute{
+							MarkdownDescription: "The start time of the execution, in RFC 3339 format; null if the execution has not started yet",
+							Computed:            true,
+						},
+						"end": schema.StringAttribute{
+							MarkdownDescription: "The end time of the execution, in RFC 3339 format; null if the execution has not ended yet",
+							Computed:            true,
+						},
+						"duration_seconds": schema.Float64Attribute{
+							MarkdownDescription: "The duration of the execution in seconds; null if the execution has not ended yet",
+							Computed:            true,
+						},
+						"log": schema.StringAttribute{
+							MarkdownDescription: "The last lines of the execution's log; null unless `log_lines` is set",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+	d.sshPool = providerutil.SSHPoolFromProviderData(req.ProviderData)
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := apiext.NewCronjobClient(d.client)
+
+	executions := providerutil.
+		Try[[]cronjobv2.CronjobExecution](&resp.Diagnostics, "error while listing cronjob executions").
+		DoVal(client.ListRecentExecutions(ctx, data.CronjobID.ValueString(), data.LimitOrDefault()))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Executions = make([]ExecutionModel, len(executions))
+	for i := range executions {
+		data.Executions[i].FromAPIModel(&executions[i])
+	}
+
+	if data.LogLines.ValueInt64() > 0 && len(executions) > 0 {
+		d.readLogs(ctx, &data, executions, resp)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readLogs reads the log excerpts of the given executions via SFTP. Logs that
+// cannot be read (for example, because they have already been rotated) are
+// reported as warnings, since the remaining information is still useful.
+func (d *DataSource) readLogs(ctx context.Context, data *DataSourceModel, executions []cronjobv2.CronjobExecution, resp *datasource.ReadResponse) {
+	cronjob := providerutil.
+		Try[*cronjobv2.Cronjob](&resp.Diagnostics, "error while fetching cronjob").
+		DoValResp(d.client.Cronjob().GetCronjob(ctx, cronjobclientv2.GetCronjobRequest{CronjobID: data.CronjobID.ValueString()}))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	details, err := apiext.ResolveSSHConnectionDetails(ctx, d.client, apiext.SSHTarget{
+		ProjectID: cronjob.ProjectId,
+		User:      data.SSHUser.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("SSH Connection Error", "Could not determine SSH connection details: "+err.Error())
+		return
+	}
+
+	// Data sources have no state to pin host keys in; see
+	// sshutil.HostKeyPins.Verify
+	session, err := d.sshPool.AcquireSFTP(ctx, details.Host, details.User, data.SSHPrivateKey.ValueString(), nil, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError("SSH Connection Error", fmt.Sprintf("Could not connect to %s: %s", details.Host, err))
+		return
+	}
+	defer func() { _ = session.Close() }()
+
+	for i, execution := range executions {
+		if execution.LogPath == nil || *execution.LogPath == "" {
+			continue
+		}
+
+		log, err := sshutil.ReadFileTail(session.Client, *execution.LogPath, int(data.LogLines.ValueInt64()))
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(path.Root("executions").AtListIndex(i).AtName("log"), "Error Reading Execution Log", err.Error())
+			continue
+		}
+
+		data.Executions[i].Log = types.StringValue(log)
+	}
+}
@@ -0,0 +1,59 @@
+package cronjobexecutionsdatasource
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/cronjobv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+)
+
+// DataSourceModel describes the data source data model.
+type DataSourceModel struct {
+	CronjobID     types.String `tfsdk:"cronjob_id"`
+	Limit         types.Int64  `tfsdk:"limit"`
+	LogLines      types.Int64  `tfsdk:"log_lines"`
+	SSHUser       types.String `tfsdk:"ssh_user"`
+	SSHPrivateKey types.String `tfsdk:"ssh_private_key"`
+
+	Executions []ExecutionModel `tfsdk:"executions"`
+}
+
+type ExecutionModel struct {
+	ID              types.String  `tfsdk:"id"`
+	Status          types.String  `tfsdk:"status"`
+	Successful      types.Bool    `tfsdk:"successful"`
+	Start           types.String  `tfsdk:"start"`
+	End             types.String  `tfsdk:"end"`
+	DurationSeconds types.Float64 `tfsdk:"duration_seconds"`
+	Log             types.String  `tfsdk:"log"`
+}
+
+func (m *DataSourceModel) LimitOrDefault() int64 {
+	if m.Limit.IsNull() {
+		return 10
+	}
+	return m.Limit.ValueInt64()
+}
+
+func (m *ExecutionModel) FromAPIModel(execution *cronjobv2.CronjobExecution) {
+	m.ID = types.StringValue(execution.Id)
+	m.Status = types.StringValue(string(execution.Status))
+	m.Successful = types.BoolValue(apiext.CronjobExecutionIsSuccessful(execution.Status))
+	m.Start = timeOrNull(execution.Start)
+	m.End = timeOrNull(execution.End)
+	m.Log = types.StringNull()
+
+	if execution.DurationInMilliseconds != nil {
+		m.DurationSeconds = types.Float64Value(float64(*execution.DurationInMilliseconds) / 1000)
+	} else {
+		m.DurationSeconds = types.Float64Null()
+	}
+}
+
+func timeOrNull(t *time.Time) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(t.Format(time.RFC3339))
+}
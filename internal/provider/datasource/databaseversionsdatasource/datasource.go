@@ -0,0 +1,145 @@
+package databaseversionsdatasource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/databaseclientv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DataSource{}
+
+type listVersionsFunc func(ctx context.Context, client databaseclientv2.Client, projectID string) (apiext.DatabaseVersionSet, error)
+
+// DataSource selects a version of a database engine; use NewMySQL or NewRedis
+// to create one.
+type DataSource struct {
+	client mittwaldv2.Client
+
+	name         string
+	engine       string
+	resource     string
+	example      string
+	listVersions listVersionsFunc
+}
+
+func NewMySQL() datasource.DataSource {
+	return &DataSource{
+		name:         "mysql_versions",
+		engine:       "MySQL",
+		resource:     "mittwald_mysql_database",
+		example:      "~8.0",
+		listVersions: apiext.ListMySQLVersions,
+	}
+}
+
+func NewRedis() datasource.DataSource {
+	return &DataSource{
+		name:         "redis_versions",
+		engine:       "Redis",
+		resource:     "mittwald_redis_database",
+		example:      "~7.2",
+		listVersions: apiext.ListRedisVersions,
+	}
+}
+
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.name
+}
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(`A data source that selects a version of %[1]s.
+
+This data source should typically be used in conjunction with the `+"`%[2]s`"+`
+resource to select the respective version for the `+"`version`"+` attribute. A warning
+is emitted when the selected version is deprecated or has reached its end of life.
+
+Versions that are marked as disabled by the API are considered deprecated or end-of-life:
+they can no longer be used for new databases, and are only selected if no other version
+matches the selector.`, d.engine, d.resource),
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project to list the available versions for; if omitted, all versions are listed. Must be a full UUID (not a short ID like p-XXXXXX).",
+				Optional:            true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+			},
+			"selector": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Either `%s` to select the latest version that is not deprecated, or a version selector, such as `%s`; for a version selector, the latest matching version is selected, preferring versions that are not deprecated. Defaults to `%s`.", SelectorRecommended, d.example, SelectorRecommended),
+				Optional:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The selected version",
+				Computed:            true,
+			},
+			"versions": schema.ListAttribute{
+				MarkdownDescription: "All available versions (including deprecated ones), regardless of the selector, in ascending order",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	versions, err := d.listVersions(ctx, d.client.Database(), data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to list %s versions", d.engine), err.Error())
+		return
+	}
+
+	selected, err := selectVersion(versions, data.SelectorOrDefault())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("selector"), fmt.Sprintf("No %s version found", d.engine), err.Error())
+		return
+	}
+
+	if selected.Disabled {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("selector"),
+			fmt.Sprintf("Deprecated %s version", d.engine),
+			fmt.Sprintf("%s %s is deprecated or has reached its end of life; it can no longer be used for new databases. Consider upgrading to a newer version.", d.engine, selected.Number),
+		)
+	}
+
+	numbers := make([]string, len(versions))
+	for i, version := range versions {
+		numbers[i] = version.Number
+	}
+
+	var diags diag.Diagnostics
+
+	data.Version = types.StringValue(selected.Number)
+	data.Versions, diags = types.ListValueFrom(ctx, types.StringType, numbers)
+	resp.Diagnostics.Append(diags...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,22 @@
+package databaseversionsdatasource
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// SelectorRecommended selects the recommended (latest non-deprecated) version.
+const SelectorRecommended = "recommended"
+
+// DataSourceModel describes the data source data model.
+type DataSourceModel struct {
+	ProjectID types.String `tfsdk:"project_id"`
+	Selector  types.String `tfsdk:"selector"`
+
+	Version  types.String `tfsdk:"version"`
+	Versions types.List   `tfsdk:"versions"`
+}
+
+func (m *DataSourceModel) SelectorOrDefault() string {
+	if m.Selector.IsNull() {
+		return SelectorRecommended
+	}
+	return m.Selector.ValueString()
+}
@@ -0,0 +1,37 @@
+package databaseversionsdatasource
+
+import (
+	"fmt"
+
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+)
+
+// selectVersion selects a version from the available versions. The selector
+// is either SelectorRecommended or a version constraint (like "~8.0"); for a
+// constraint, the latest matching version is selected, preferring versions
+// that are not deprecated.
+func selectVersion(versions apiext.DatabaseVersionSet, selector string) (*apiext.DatabaseVersion, error) {
+	if selector == SelectorRecommended {
+		recommended, ok := versions.Recommended()
+		if !ok {
+			return nil, fmt.Errorf("no recommended version available")
+		}
+
+		return recommended, nil
+	}
+
+	matching, err := versions.FilterByConstraintStr(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matching) == 0 {
+		return nil, fmt.Errorf("no version matching '%s' available", selector)
+	}
+
+	if recommended, ok := matching.Recommended(); ok {
+		return recommended, nil
+	}
+
+	return &matching[len(matching)-1], nil
+}
@@ -0,0 +1,45 @@
+package databaseversionsdatasource
+
+import (
+	"testing"
+
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	. "github.com/onsi/gomega"
+)
+
+func TestSelectVersion(t *testing.T) {
+	versions := apiext.DatabaseVersionSet{
+		{ID: "1", Number: "5.7", Disabled: true},
+		{ID: "2", Number: "8.0"},
+		{ID: "3", Number: "8.4"},
+	}
+
+	tests := []struct {
+		selector string
+		expected string
+		disabled bool
+	}{
+		{selector: SelectorRecommended, expected: "8.4"},
+		{selector: "~8.0", expected: "8.0"},
+		{selector: ">= 5", expected: "8.4"},
+		{selector: "~5.7", expected: "5.7", disabled: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.selector, func(t *testing.T) {
+			g := NewWithT(t)
+
+			selected, err := selectVersion(versions, test.selector)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(selected.Number).To(Equal(test.expected))
+			g.Expect(selected.Disabled).To(Equal(test.disabled))
+		})
+	}
+
+	t.Run("no match", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := selectVersion(versions, "~9")
+		g.Expect(err).To(MatchError(ContainSubstring("no version matching")))
+	})
+}
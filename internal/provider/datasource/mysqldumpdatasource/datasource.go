@@ -0,0 +1,69 @@
+package mysqldumpdatasource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mittwald/terraform-provider-mittwald/internal/dumputil"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DataSource{}
+
+func New() datasource.DataSource {
+	return &DataSource{}
+}
+
+// DataSource defines the data source implementation.
+type DataSource struct{}
+
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mysql_dump"
+}
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A data source that reads a local MySQL dump that was written by the `mittwald_mysql_dump` action, " +
+			"verifies it against the checksum file that was written next to it, and exposes its checksum and size.\n\n" +
+			"Reading fails if the dump or its checksum file do not exist, or if the dump does not match its checksum " +
+			"(for example, because it was modified after it was written).",
+
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The local path of the dump; this is the `destination` of the `mittwald_mysql_dump` action.",
+				Required:            true,
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "The hex-encoded SHA-256 checksum of the dump file.",
+				Computed:            true,
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "The size of the dump file in bytes (after compression, if the dump is gzip-compressed).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checksum, size, err := dumputil.VerifyDumpFile(data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Invalid MySQL Dump", err.Error())
+		return
+	}
+
+	data.SHA256 = types.StringValue(checksum)
+	data.Size = types.Int64Value(size)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
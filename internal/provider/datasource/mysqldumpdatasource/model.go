@@ -0,0 +1,12 @@
+package mysqldumpdatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DataSourceModel describes the data source data model.
+type DataSourceModel struct {
+	Path   types.String `tfsdk:"path"`
+	SHA256 types.String `tfsdk:"sha256"`
+	Size   types.Int64  `tfsdk:"size"`
+}
@@ -0,0 +1,201 @@
+package remotefiledatasource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DataSource{}
+
+func New() datasource.DataSource {
+	return &DataSource{}
+}
+
+// DataSource defines the data source implementation.
+type DataSource struct {
+	client  mittwaldv2.Client
+	sshPool *sshutil.Pool
+}
+
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_remote_file"
+}
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A data source that reads a file from an app installation or a container via SSH.\n\n" +
+			"In contrast to the `mittwald_remote_file` resource, this data source does not manage the file; " +
+			"it can be used to read files that are generated on the remote side, like an app's configuration " +
+			"file. You can specify either a container_id or an app_id to determine which server to connect to.",
+
+		Attributes: map[string]schema.Attribute{
+			"container_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the container to read the file from. Either this or `app_id` must be specified.",
+				Optional:            true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+			},
+			"stack_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the stack that the container belongs to; required when `container_id` is specified.",
+				Optional:            true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+			},
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the app installation to read the file from. Either this or `container_id` must be specified.",
+				Optional:            true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+			},
+			"ssh_user": schema.StringAttribute{
+				MarkdownDescription: "The SSH username to use for the connection; defaults to the currently authenticated user.",
+				Optional:            true,
+			},
+			"ssh_private_key": schema.StringAttribute{
+				MarkdownDescription: "The SSH private key to use for the connection. If not specified, " + sshutil.DefaultAuthDescription + ".",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The absolute path of the file on the remote server.",
+				Required:            true,
+			},
+			"contents": schema.StringAttribute{
+				MarkdownDescription: "The contents of the file; this is null if the file is not valid UTF-8 (use `content_base64` instead).",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "The contents of the file, base64-encoded.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "The size of the file in bytes.",
+				Computed:            true,
+			},
+			"mode": schema.StringAttribute{
+				MarkdownDescription: "The file mode (permissions), in octal notation (like `0644`).",
+				Computed:            true,
+			},
+			"mtime": schema.StringAttribute{
+				MarkdownDescription: "The time of the last modification of the file, in RFC 3339 format.",
+				Computed:            true,
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "The hex-encoded SHA-256 hash of the file contents.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+	d.sshPool = providerutil.SSHPoolFromProviderData(req.ProviderData)
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ContainerID.IsNull() == data.AppID.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("container_id"), "Invalid Resource Reference", "Exactly one of container_id or app_id must be specified.")
+		return
+	}
+
+	if !data.ContainerID.IsNull() && data.StackID.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("stack_id"), "Missing Stack ID", "stack_id must be specified when container_id is specified.")
+		return
+	}
+
+	details, err := apiext.ResolveSSHConnectionDetails(ctx, d.client, apiext.SSHTarget{
+		AppID:       data.AppID.ValueString(),
+		ContainerID: data.ContainerID.ValueString(),
+		StackID:     data.StackID.ValueString(),
+		User:        data.SSHUser.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("SSH Connection Error", "Could not determine SSH connection details: "+err.Error())
+		return
+	}
+
+	// Data sources have no state to pin host keys in; see
+	// sshutil.HostKeyPins.Verify
+	session, err := d.sshPool.AcquireSFTP(ctx, details.Host, details.User, data.SSHPrivateKey.ValueString(), nil, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError("SSH Connection Error", fmt.Sprintf("Could not connect to %s: %s", details.Host, err))
+		return
+	}
+	defer func() { _ = session.Close() }()
+
+	filePath := data.Path.ValueString()
+
+	info, err := session.Stat(filePath)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Error Reading Remote File", fmt.Sprintf("Could not read file at %s: %s", filePath, err))
+		return
+	}
+
+	if !info.Mode().IsRegular() {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Error Reading Remote File", fmt.Sprintf("%s is not a regular file", filePath))
+		return
+	}
+
+	file, err := session.Open(filePath)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Error Reading Remote File", fmt.Sprintf("Could not open file at %s: %s", filePath, err))
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+
+	contents, err := io.ReadAll(io.TeeReader(file, hash))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Remote File", fmt.Sprintf("Could not read file at %s: %s", filePath, err))
+		return
+	}
+
+	if utf8.Valid(contents) {
+		data.Contents = types.StringValue(string(contents))
+	} else {
+		data.Contents = types.StringNull()
+	}
+
+	data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(contents))
+	data.Size = types.Int64Value(int64(len(contents)))
+	data.Mode = types.StringValue(sshutil.FormatFileMode(info.Mode()))
+	data.ModifiedAt = types.StringValue(info.ModTime().UTC().Format(time.RFC3339))
+	data.SHA256 = types.StringValue(hex.EncodeToString(hash.Sum(nil)))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
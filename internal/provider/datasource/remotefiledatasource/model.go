@@ -0,0 +1,22 @@
+package remotefiledatasource
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DataSourceModel describes the data source data model.
+type DataSourceModel struct {
+	ContainerID   types.String `tfsdk:"container_id"`
+	StackID       types.String `tfsdk:"stack_id"`
+	AppID         types.String `tfsdk:"app_id"`
+	SSHUser       types.String `tfsdk:"ssh_user"`
+	SSHPrivateKey types.String `tfsdk:"ssh_private_key"`
+	Path          types.String `tfsdk:"path"`
+
+	Contents      types.String `tfsdk:"contents"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	Size          types.Int64  `tfsdk:"size"`
+	Mode          types.String `tfsdk:"mode"`
+	ModifiedAt    types.String `tfsdk:"mtime"`
+	SHA256        types.String `tfsdk:"sha256"`
+}
@@ -2,8 +2,10 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
@@ -11,32 +13,51 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/mittwald/api-client-go/mittwaldv2"
 	"github.com/mittwald/terraform-provider-mittwald/internal/logadapter"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/appexecaction"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/applifecycleaction"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/appupgradeaction"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/containerexecaction"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/containerrecreateaction"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/containerrestartaction"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/cronjobabortaction"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/cronjobtriggeraction"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/mysqldumpaction"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/action/mysqlimportaction"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/datasource/appdatasource"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/datasource/appsdatasource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/datasource/articledatasource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/datasource/containerimagedatasource"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/datasource/containerlogsdatasource"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/datasource/cronjobexecutionsdatasource"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/datasource/databaseversionsdatasource"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/datasource/mysqldumpdatasource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/datasource/projectdatasource"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/datasource/remotefiledatasource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/datasource/serverdatasource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/datasource/systemsoftwaredatasource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/datasource/userdatasource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/function/readsshpublickey"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/aiapikeyresource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/airesource"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/appcopyresource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/appresource"
 	containerregistryresource "github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/containerregistry"
 	containerstackresource "github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/containerstack"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/cronjobresource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/emailoutboxresource"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/mysqldatabasecopyresource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/mysqldatabaseresource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/mysqlpassword"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/projectresource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/redisdatabaseresource"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/remotedirectoryresource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/remotefileresource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/serverresource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/sshuserresource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/tlscertificateresource"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/virtualhostresource"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
@@ -63,6 +84,12 @@ type MittwaldProviderModel struct {
 	Endpoint           types.String `tfsdk:"endpoint"`
 	APIKey             types.String `tfsdk:"api_key"`
 	DebugRequestBodies types.Bool   `tfsdk:"debug_request_bodies"`
+
+	SSHKnownHostsFile        types.String `tfsdk:"ssh_known_hosts_file"`
+	SSHStrictHostKeyChecking types.Bool   `tfsdk:"ssh_strict_host_key_checking"`
+	SSHPrivateKeyPassphrase  types.String `tfsdk:"ssh_private_key_passphrase"`
+	SSHIdleTimeout           types.String `tfsdk:"ssh_idle_timeout"`
+	SSHMaxConcurrentSessions types.Int64  `tfsdk:"ssh_max_concurrent_sessions"`
 }
 
 func (p *MittwaldProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -87,6 +114,27 @@ func (p *MittwaldProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				MarkdownDescription: "Whether to log request bodies when debugging is enabled. CAUTION: This will log sensitive data such as passwords in plain text!",
 				Optional:            true,
 			},
+			"ssh_known_hosts_file": schema.StringAttribute{
+				MarkdownDescription: "Path of an OpenSSH `known_hosts` file that is used to verify SSH host keys (for example, for `mittwald_remote_file` resources), in addition to the host keys of the mittwald clusters that are built into the provider.",
+				Optional:            true,
+			},
+			"ssh_strict_host_key_checking": schema.BoolAttribute{
+				MarkdownDescription: "Whether to refuse SSH connections to hosts whose host key cannot be verified using the known hosts file or the host keys of the mittwald clusters that are built into the provider. If disabled (the default), unknown host keys are trusted on first use: resources (like `mittwald_remote_file`) pin the host key in their private state and refuse to connect if it changes later on, while actions accept unknown host keys with a warning. Mismatching host keys are always refused; when enabled, host keys that were pinned before are refused as well, unless they can be verified.",
+				Optional:            true,
+			},
+			"ssh_private_key_passphrase": schema.StringAttribute{
+				MarkdownDescription: "Passphrase for encrypted SSH private keys. If not set, encrypted keys can only be used via an SSH agent.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"ssh_idle_timeout": schema.StringAttribute{
+				MarkdownDescription: "Duration after which unused SSH connections are closed, as a Go duration string (like `\"30s\"` or `\"2m\"`); defaults to `\"" + sshutil.DefaultIdleTimeout.String() + "\"`. SSH connections are shared between all resources, data sources and actions that connect to the same host as the same user.",
+				Optional:            true,
+			},
+			"ssh_max_concurrent_sessions": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of SSH operations (like file uploads or command executions) that may run concurrently; defaults to `%d`.", sshutil.DefaultMaxConcurrentSessions),
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -124,15 +172,43 @@ func (p *MittwaldProvider) Configure(ctx context.Context, req provider.Configure
 	logger := slog.New(&logadapter.TFLHandler{})
 	opts = append(opts, mittwaldv2.WithRequestLogging(logger, data.DebugRequestBodies.ValueBool(), data.DebugRequestBodies.ValueBool()))
 
+	sshConfig := sshutil.Config{
+		KnownHostsFile:        data.SSHKnownHostsFile.ValueString(),
+		StrictHostKeyChecking: data.SSHStrictHostKeyChecking.ValueBool(),
+		PrivateKeyPassphrase:  data.SSHPrivateKeyPassphrase.ValueString(),
+		MaxConcurrentSessions: int(data.SSHMaxConcurrentSessions.ValueInt64()),
+	}
+
+	if !data.SSHIdleTimeout.IsNull() {
+		idleTimeout, err := time.ParseDuration(data.SSHIdleTimeout.ValueString())
+		if err != nil || idleTimeout <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("ssh_idle_timeout"), "invalid SSH idle timeout", fmt.Sprintf("%q is not a valid positive duration", data.SSHIdleTimeout.ValueString()))
+		}
+		sshConfig.IdleTimeout = idleTimeout
+	}
+
+	if data.SSHMaxConcurrentSessions.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("ssh_max_concurrent_sessions"), "invalid SSH session limit", "the maximum number of concurrent SSH sessions must not be negative")
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	client, err := mittwaldv2.New(ctx, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError("error initializing API client", err.Error())
 		return
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
-	resp.ActionData = client
+	providerData := &providerutil.ProviderData{
+		Client: client,
+		SSH:    sshutil.NewPool(sshConfig),
+	}
+
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.ActionData = providerData
 }
 
 func (p *MittwaldProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -142,7 +218,9 @@ func (p *MittwaldProvider) Resources(_ context.Context) []func() resource.Resour
 		serverresource.New,
 		projectresource.New,
 		appresource.New,
+		appcopyresource.New,
 		mysqldatabaseresource.New,
+		mysqldatabasecopyresource.New,
 		redisdatabaseresource.New,
 		cronjobresource.New,
 		virtualhostresource.New,
@@ -150,6 +228,7 @@ func (p *MittwaldProvider) Resources(_ context.Context) []func() resource.Resour
 		containerregistryresource.New,
 		emailoutboxresource.New,
 		remotefileresource.New,
+		remotedirectoryresource.New,
 		sshuserresource.New,
 		tlscertificateresource.New,
 	}
@@ -162,9 +241,16 @@ func (p *MittwaldProvider) DataSources(_ context.Context) []func() datasource.Da
 		serverdatasource.New,
 		systemsoftwaredatasource.New,
 		appdatasource.New,
+		appsdatasource.New,
+		databaseversionsdatasource.NewMySQL,
+		databaseversionsdatasource.NewRedis,
 		articledatasource.New,
 		userdatasource.New,
 		containerimagedatasource.New,
+		containerlogsdatasource.New,
+		cronjobexecutionsdatasource.New,
+		mysqldumpdatasource.New,
+		remotefiledatasource.New,
 	}
 }
 
@@ -178,6 +264,16 @@ func (p *MittwaldProvider) Actions(context.Context) []func() action.Action {
 	return []func() action.Action{
 		containerrestartaction.New,
 		containerrecreateaction.New,
+		containerexecaction.New,
+		appexecaction.New,
+		appupgradeaction.New,
+		applifecycleaction.NewStart,
+		applifecycleaction.NewStop,
+		applifecycleaction.NewRestart,
+		mysqlimportaction.New,
+		mysqldumpaction.New,
+		cronjobtriggeraction.New,
+		cronjobabortaction.New,
 	}
 }
 
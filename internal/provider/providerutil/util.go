@@ -4,8 +4,16 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
 )
 
+// ProviderData is passed from the provider to resources, data sources and
+// actions during configuration.
+type ProviderData struct {
+	Client mittwaldv2.Client
+	SSH    *sshutil.Pool
+}
+
 // ClientFromProviderData is a helper function to extract the client from the
 // provider data.
 func ClientFromProviderData(providerData any, d *diag.Diagnostics) mittwaldv2.Client {
@@ -13,16 +21,28 @@ func ClientFromProviderData(providerData any, d *diag.Diagnostics) mittwaldv2.Cl
 		return nil
 	}
 
-	client, ok := providerData.(mittwaldv2.Client)
+	switch data := providerData.(type) {
+	case *ProviderData:
+		return data.Client
+	case mittwaldv2.Client:
+		return data
+	}
+
+	d.AddError(
+		"mittwald API client has unexpected type",
+		fmt.Sprintf("Expected *providerutil.ProviderData, got: %T. Please report this issue to the provider developers at https://github.com/mittwald/terraform-provider-mittwald/issues.", providerData),
+	)
 
-	if !ok {
-		d.AddError(
-			"mittwald API client has unexpected type",
-			fmt.Sprintf("Expected mittwaldv2.Client, got: %T. Please report this issue to the provider developers at https://github.com/mittwald/terraform-provider-mittwald/issues.", providerData),
-		)
+	return nil
+}
 
-		return nil
+// SSHPoolFromProviderData is a helper function to extract the shared SSH
+// connection pool from the provider data. If the provider data does not
+// contain a pool, a new pool with the default configuration is returned.
+func SSHPoolFromProviderData(providerData any) *sshutil.Pool {
+	if data, ok := providerData.(*ProviderData); ok && data.SSH != nil {
+		return data.SSH
 	}
 
-	return client
+	return sshutil.NewPool(sshutil.Config{})
 }
@@ -0,0 +1,15 @@
+package appcopyresource
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type ResourceModel struct {
+	ID                       types.String `tfsdk:"id"`
+	ShortID                  types.String `tfsdk:"short_id"`
+	SourceAppID              types.String `tfsdk:"source_app_id"`
+	ProjectID                types.String `tfsdk:"project_id"`
+	Description              types.String `tfsdk:"description"`
+	App                      types.String `tfsdk:"app"`
+	Version                  types.String `tfsdk:"version"`
+	InstallationPath         types.String `tfsdk:"installation_path"`
+	InstallationPathAbsolute types.String `tfsdk:"installation_path_absolute"`
+}
@@ -0,0 +1,71 @@
+package appcopyresource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/appclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/projectclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/projectv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+)
+
+func (m *ResourceModel) ToCreateRequest() appclientv2.RequestAppinstallationCopyRequest {
+	return appclientv2.RequestAppinstallationCopyRequest{
+		AppInstallationID: m.SourceAppID.ValueString(),
+		Body: appclientv2.RequestAppinstallationCopyRequestBody{
+			Description:     m.Description.ValueString(),
+			TargetProjectId: m.ProjectID.ValueString(),
+		},
+	}
+}
+
+func (m *ResourceModel) ToDeleteRequest() appclientv2.UninstallAppinstallationRequest {
+	return appclientv2.UninstallAppinstallationRequest{
+		AppInstallationID: m.ID.ValueString(),
+	}
+}
+
+func (m *ResourceModel) FromAPIModel(ctx context.Context, appInstallation *appv2.AppInstallation, client mittwaldv2.Client) (res diag.Diagnostics) {
+	appClient := client.App()
+
+	// The copy is expected to run the same version as the source installation;
+	// the current version is preferred, since it is the one that is actually
+	// installed.
+	versionID := appInstallation.AppVersion.Desired
+	if appInstallation.AppVersion.Current != nil {
+		versionID = *appInstallation.AppVersion.Current
+	}
+
+	appVersion := providerutil.
+		Try[*appv2.AppVersion](&res, "error while fetching app version").
+		DoValResp(appClient.GetAppversion(ctx, appclientv2.GetAppversionRequest{AppID: appInstallation.AppId, AppVersionID: versionID}))
+
+	project := providerutil.
+		Try[*projectv2.Project](&res, "error while fetching project").
+		DoValResp(client.Project().GetProject(ctx, projectclientv2.GetProjectRequest{ProjectID: appInstallation.ProjectId}))
+
+	if res.HasError() {
+		return
+	}
+
+	m.ShortID = types.StringValue(appInstallation.ShortId)
+	m.ProjectID = types.StringValue(appInstallation.ProjectId)
+	m.Description = types.StringValue(appInstallation.Description)
+	m.Version = types.StringValue(appVersion.InternalVersion)
+	m.InstallationPath = types.StringValue(appInstallation.InstallationPath)
+	m.InstallationPathAbsolute = types.StringValue(project.Directories["Web"] + appInstallation.InstallationPath)
+
+	m.App = types.StringNull()
+	for key, appID := range apiext.AppNames {
+		if appID == appInstallation.AppId {
+			m.App = types.StringValue(key)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,214 @@
+package appcopyresource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/appclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &Resource{}
+
+func New() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client mittwaldv2.Client
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_copy"
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	builder := common.AttributeBuilderFor("app copy")
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: description,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Models a copy of an existing app installation, for example for a staging environment.\n\n" +
+			"The copy is created from the current state of the source installation, and is not kept in sync " +
+			"with it afterwards. Destroying this resource uninstalls only the copy; the source installation " +
+			"is not affected.",
+		Attributes: map[string]schema.Attribute{
+			"id":       builder.Id(),
+			"short_id": computed("The short ID of the app copy"),
+			"source_app_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the app installation to copy. Must be a full UUID (not a short ID like a-XXXXXX).",
+				Required:            true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_id":                 builder.ProjectId(),
+			"description":                builder.Description(),
+			"app":                        computed("The name of the app"),
+			"version":                    computed("The version of the app"),
+			"installation_path":          computed("The installation path of the app copy, relative to the web root"),
+			"installation_path_absolute": computed("The absolute installation path of the app copy, including the web root"),
+		},
+	}
+}
+
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	data := ResourceModel{}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appClient := apiext.NewAppClient(r.client)
+
+	// Copying an installation that is currently being (re)configured fails, so
+	// wait for the source to settle first.
+	try := providerutil.Try[any](&resp.Diagnostics, "error while waiting for source app installation")
+	try.Do(appClient.WaitUntilAppInstallationIsReady(ctx, data.SourceAppID.ValueString()))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	installation := providerutil.
+		Try[*appclientv2.RequestAppinstallationCopyResponse](&resp.Diagnostics, "error while requesting app installation copy").
+		DoValResp(appClient.RequestAppinstallationCopy(ctx, data.ToCreateRequest()))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(installation.Id)
+
+	// Store the ID right away, so that the copy is tainted (instead of
+	// orphaned) if any of the following steps fail.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), data.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_app_id"), data.SourceAppID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), data.ProjectID)...)
+
+	providerutil.
+		Try[any](&resp.Diagnostics, "error while waiting for app installation copy").
+		Do(appClient.WaitUntilAppInstallationIsReady(ctx, installation.Id))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, diags := r.read(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if !found && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError("error while reading app installation copy", "the app installation copy was created, but could not be read back")
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	data := ResourceModel{}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, diags := r.read(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// read refreshes the given model from the API. It reports whether the app
+// installation still exists.
+func (r *Resource) read(ctx context.Context, data *ResourceModel) (bool, diag.Diagnostics) {
+	var res diag.Diagnostics
+
+	appInstallation := providerutil.
+		Try[*appv2.AppInstallation](&res, "error while fetching app installation").
+		IgnoreNotFound().
+		DoValResp(r.client.App().GetAppinstallation(ctx, appclientv2.GetAppinstallationRequest{AppInstallationID: data.ID.ValueString()}))
+
+	if res.HasError() || appInstallation == nil {
+		return false, res
+	}
+
+	res.Append(data.FromAPIModel(ctx, appInstallation, r.client)...)
+
+	return true, res
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	planData := ResourceModel{}
+	currentData := ResourceModel{}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appClient := apiext.NewAppClient(r.client)
+
+	if !planData.Description.Equal(currentData.Description) {
+		providerutil.
+			Try[any](&resp.Diagnostics, "error while updating app installation copy").
+			Do(appClient.UpdateAppinstallation(ctx, planData.ID.ValueString(), apiext.UpdateAppInstallationDescription(planData.Description.ValueString())))
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := r.read(ctx, &planData)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerutil.
+		Try[any](&resp.Diagnostics, "error while uninstalling app installation copy").
+		IgnoreNotFound().
+		DoResp(r.client.App().UninstallAppinstallation(ctx, data.ToDeleteRequest()))
+}
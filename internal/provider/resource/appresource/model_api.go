@@ -61,15 +61,18 @@ func (m *ResourceModel) ToCreateRequest(ctx context.Context, d *diag.Diagnostics
 		}
 	}
 
-	for key, value := range m.UserInputs.Elements() {
-		if s, ok := value.(types.String); ok {
-			b.UserInputs = append(b.UserInputs, appv2.SavedUserInput{
-				Name:  key,
-				Value: s.ValueString(),
-			})
-		} else {
-			d.AddAttributeError(path.Root("user_inputs").AtMapKey(key), "invalid type", fmt.Sprintf("expected string, got %T", value))
-		}
+	for key, value := range m.userInputs(true, d) {
+		b.UserInputs = append(b.UserInputs, appv2.SavedUserInput{
+			Name:  key,
+			Value: value,
+		})
+	}
+
+	if startCommand, ok := m.startCommand(d); ok {
+		b.UserInputs = append(b.UserInputs, appv2.SavedUserInput{
+			Name:  apiext.StartCommandUserInput,
+			Value: startCommand,
+		})
 	}
 
 	b.SystemSoftware = providerutil.
@@ -79,7 +82,7 @@ func (m *ResourceModel) ToCreateRequest(ctx context.Context, d *diag.Diagnostics
 	return
 }
 
-func (m *ResourceModel) ToUpdateUpdaters(ctx context.Context, d diag.Diagnostics, current *ResourceModel, appClient apiext.AppClient) []apiext.AppInstallationUpdater {
+func (m *ResourceModel) ToUpdateUpdaters(ctx context.Context, d *diag.Diagnostics, current *ResourceModel, appClient apiext.AppClient) []apiext.AppInstallationUpdater {
 	updaters := make([]apiext.AppInstallationUpdater, 0)
 
 	if !m.Description.Equal(current.Description) {
@@ -94,9 +97,17 @@ func (m *ResourceModel) ToUpdateUpdaters(ctx context.Context, d diag.Diagnostics
 		updaters = append(updaters, apiext.UpdateAppInstallationUpdatePolicy(appv2.AppUpdatePolicy(m.UpdatePolicy.ValueString())))
 	}
 
+	// Sensitive user inputs are not stored in the state, so changes to them can
+	// only be detected by their version. The start command is stored in a user
+	// input, too.
+	sensitiveUserInputsChanged := !m.SensitiveUserInputsVersion.Equal(current.SensitiveUserInputsVersion)
+	if !m.UserInputs.Equal(current.UserInputs) || sensitiveUserInputsChanged || m.startCommandChanged(current) {
+		updaters = append(updaters, m.userInputsUpdater(ctx, appClient, d))
+	}
+
 	if !m.Dependencies.Equal(current.Dependencies) {
 		depUpdater := providerutil.
-			Try[apiext.AppInstallationUpdater](&d, "error while building dependency updaters").
+			Try[apiext.AppInstallationUpdater](d, "error while building dependency updaters").
 			DoVal(m.dependenciesToUpdater(ctx, appClient, &current.Dependencies))
 		updaters = append(updaters, depUpdater)
 	}
@@ -178,6 +189,8 @@ func (m *ResourceModel) FromAPIModel(ctx context.Context, appInstallation *appv2
 		m.Databases, _ = types.SetValue(&databaseModelAttrType, nil)
 	}
 
+	m.userInputsFromAPIModel(appInstallation.UserInputs, &res)
+
 	if appInstallation.AppVersion.Current != nil {
 		appCurrentVersion := providerutil.
 			Try[*appv2.AppVersion](&res, "error while fetching app version").
@@ -0,0 +1,102 @@
+package appresource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/shellutil"
+)
+
+// injectSensitiveEnvironment copies the write-only `sensitive_environment`
+// from the configuration into the model; see injectSensitiveUserInputs.
+func (m *ResourceModel) injectSensitiveEnvironment(ctx context.Context, config tfsdk.Config, d *diag.Diagnostics) {
+	d.Append(config.GetAttribute(ctx, path.Root("sensitive_environment"), &m.SensitiveEnvironment)...)
+}
+
+// startCommandChanged reports whether the start command (or its environment)
+// needs to be updated, compared to the current state. Sensitive environment
+// variables are not stored in the state, so changes to them can only be
+// detected by their version.
+func (m *ResourceModel) startCommandChanged(current *ResourceModel) bool {
+	if m.StartCommand.IsNull() {
+		return false
+	}
+
+	return !m.StartCommand.Equal(current.StartCommand) ||
+		!m.Environment.Equal(current.Environment) ||
+		!m.SensitiveEnvironmentVersion.Equal(current.SensitiveEnvironmentVersion)
+}
+
+// validateStartCommand checks that the environment is only set together with
+// a start command, and that the start command does not conflict with the user
+// inputs; see Resource.ValidateConfig.
+func (m *ResourceModel) validateStartCommand(d *diag.Diagnostics) {
+	if m.StartCommand.IsUnknown() {
+		return
+	}
+
+	if m.StartCommand.IsNull() {
+		if len(m.Environment.Elements()) > 0 || len(m.SensitiveEnvironment.Elements()) > 0 {
+			d.AddAttributeError(path.Root("start_command"), "missing start command", "environment variables can only be set for apps with a start_command")
+		}
+		return
+	}
+
+	if _, ok := m.UserInputs.Elements()[apiext.StartCommandUserInput]; ok {
+		d.AddAttributeError(path.Root("user_inputs").AtMapKey(apiext.StartCommandUserInput), "conflicting user input", fmt.Sprintf("user input %s must not be specified when start_command is set", apiext.StartCommandUserInput))
+	}
+}
+
+// startCommand builds the start command that is stored in the app's
+// apiext.StartCommandUserInput user input; ok is false if no start command is
+// configured. The configuration is expected to be valid (see
+// validateStartCommand).
+func (m *ResourceModel) startCommand(d *diag.Diagnostics) (startCommand string, ok bool) {
+	if m.StartCommand.IsNull() || m.StartCommand.IsUnknown() {
+		return "", false
+	}
+
+	env := environmentFromMap(path.Root("environment"), m.Environment, nil, d)
+	env = environmentFromMap(path.Root("sensitive_environment"), m.SensitiveEnvironment, env, d)
+
+	startCommand, err := apiext.BuildStartCommand(m.StartCommand.ValueString(), env)
+	if err != nil {
+		d.AddAttributeError(path.Root("start_command"), "invalid start command", err.Error())
+		return "", false
+	}
+
+	return startCommand, true
+}
+
+// environmentFromMap adds the environment variables from values to env,
+// reporting invalid and duplicate names at the given attribute path.
+func environmentFromMap(attrPath path.Path, values types.Map, env map[string]string, d *diag.Diagnostics) map[string]string {
+	if env == nil {
+		env = make(map[string]string)
+	}
+
+	for name, value := range values.Elements() {
+		if err := shellutil.ValidateEnvironmentVariableName(name); err != nil {
+			d.AddAttributeError(attrPath.AtMapKey(name), "invalid environment variable", err.Error())
+			continue
+		}
+
+		if _, ok := env[name]; ok {
+			d.AddAttributeError(attrPath.AtMapKey(name), "duplicate environment variable", fmt.Sprintf("environment variable %s must not be specified in both environment and sensitive_environment", name))
+			continue
+		}
+
+		if s, ok := value.(types.String); ok {
+			env[name] = s.ValueString()
+		} else {
+			d.AddAttributeError(attrPath.AtMapKey(name), "invalid type", fmt.Sprintf("expected string, got %T", value))
+		}
+	}
+
+	return env
+}
@@ -0,0 +1,110 @@
+package appresource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/appclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/appv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+)
+
+// injectSensitiveUserInputs copies the write-only `sensitive_user_inputs` from
+// the configuration into the model. Write-only values are always null in the
+// plan, so this needs to happen before building any API request that should
+// include them.
+func (m *ResourceModel) injectSensitiveUserInputs(ctx context.Context, config tfsdk.Config, d *diag.Diagnostics) {
+	d.Append(config.GetAttribute(ctx, path.Root("sensitive_user_inputs"), &m.SensitiveUserInputs)...)
+}
+
+// userInputs returns the configured user inputs; if includeSensitive is set,
+// the sensitive user inputs are included, too (see injectSensitiveUserInputs).
+func (m *ResourceModel) userInputs(includeSensitive bool, d *diag.Diagnostics) map[string]string {
+	inputs := make(map[string]string)
+
+	for key, value := range m.UserInputs.Elements() {
+		if s, ok := value.(types.String); ok {
+			inputs[key] = s.ValueString()
+		} else {
+			d.AddAttributeError(path.Root("user_inputs").AtMapKey(key), "invalid type", fmt.Sprintf("expected string, got %T", value))
+		}
+	}
+
+	if !includeSensitive {
+		return inputs
+	}
+
+	for key, value := range m.SensitiveUserInputs.Elements() {
+		if _, ok := inputs[key]; ok {
+			d.AddAttributeError(path.Root("sensitive_user_inputs").AtMapKey(key), "duplicate user input", fmt.Sprintf("user input %s must not be specified in both user_inputs and sensitive_user_inputs", key))
+			continue
+		}
+
+		if s, ok := value.(types.String); ok {
+			inputs[key] = s.ValueString()
+		} else {
+			d.AddAttributeError(path.Root("sensitive_user_inputs").AtMapKey(key), "invalid type", fmt.Sprintf("expected string, got %T", value))
+		}
+	}
+
+	return inputs
+}
+
+// userInputsUpdater builds an updater that sets all configured user inputs
+// (including the sensitive ones, see injectSensitiveUserInputs) and the start
+// command. The API replaces the user inputs as a whole, so the user inputs that
+// are currently saved for the installation are fetched and included as well.
+func (m *ResourceModel) userInputsUpdater(ctx context.Context, appClient apiext.AppClient, d *diag.Diagnostics) apiext.AppInstallationUpdater {
+	inputs := m.userInputs(true, d)
+
+	if startCommand, ok := m.startCommand(d); ok {
+		inputs[apiext.StartCommandUserInput] = startCommand
+	}
+
+	installation := providerutil.
+		Try[*appv2.AppInstallation](d, "error while fetching app installation").
+		DoValResp(appClient.GetAppinstallation(ctx, appclientv2.GetAppinstallationRequest{AppInstallationID: m.ID.ValueString()}))
+
+	var current []appv2.SavedUserInput
+	if installation != nil {
+		current = installation.UserInputs
+	}
+
+	return apiext.UpdateAppInstallationUserInputs(current, inputs)
+}
+
+// userInputsFromAPIModel reads back the managed user inputs (those that are
+// already present in the model) from the API, so that drift is detected.
+// Unmanaged user inputs are ignored; since sensitive user inputs are never
+// present in `user_inputs`, they never end up in the state. Managed user inputs
+// that are not returned by the API are left unchanged.
+func (m *ResourceModel) userInputsFromAPIModel(saved []appv2.SavedUserInput, d *diag.Diagnostics) {
+	if m.UserInputs.IsNull() || m.UserInputs.IsUnknown() {
+		return
+	}
+
+	savedByName := make(map[string]string, len(saved))
+	for _, input := range saved {
+		savedByName[input.Name] = input.Value
+	}
+
+	values := make(map[string]attr.Value, len(m.UserInputs.Elements()))
+	for key, value := range m.UserInputs.Elements() {
+		if saved, ok := savedByName[key]; ok {
+			values[key] = types.StringValue(saved)
+		} else {
+			values[key] = value
+		}
+	}
+
+	userInputs, diags := types.MapValue(types.StringType, values)
+	d.Append(diags...)
+
+	m.UserInputs = userInputs
+}
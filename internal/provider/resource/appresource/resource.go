@@ -2,6 +2,8 @@ package appresource
 
 import (
 	"context"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -24,6 +26,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithValidateConfig = &Resource{}
 
 func New() resource.Resource {
 	return &Resource{}
@@ -138,9 +141,45 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				Required:            true,
 			},
 			"user_inputs": schema.MapAttribute{
-				MarkdownDescription: "The user inputs of the app",
+				MarkdownDescription: "The user inputs of the app, like the site title or the name of the admin user.\n\n" +
+					"    Changes to the user inputs are detected and applied to the existing installation; note that some apps do not support changing all of their user inputs after the installation. Sensitive user inputs (like passwords) should be specified in `sensitive_user_inputs` instead.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"sensitive_user_inputs": schema.MapAttribute{
+				MarkdownDescription: "Sensitive user inputs of the app, like the password of the admin user. These are sent to the API when the app is installed or `sensitive_user_inputs_version` changes, but are never stored in the state.\n\n" +
+					"    User inputs must not be declared in both `user_inputs` and `sensitive_user_inputs`.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				ElementType: types.StringType,
+			},
+			"sensitive_user_inputs_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of the sensitive user inputs. Since the sensitive values are not stored in the state, changes to them can not be detected; increment this value to apply them.",
+				Optional:            true,
+			},
+			"start_command": schema.StringAttribute{
+				MarkdownDescription: "The command that is used to start the app; only applicable to custom apps, like Node.js or Python apps. Changing the start command restarts the app.\n\n" +
+					"    Removing this attribute leaves the current start command of the app unchanged.",
+				Optional: true,
+			},
+			"environment": schema.MapAttribute{
+				MarkdownDescription: "Environment variables for the app; requires `start_command` to be set. Changing the environment variables restarts the app.\n\n" +
+					"    The API has no separate setting for environment variables, so these are exported by the start command of the app. Sensitive environment variables should be specified in `sensitive_environment` instead.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"sensitive_environment": schema.MapAttribute{
+				MarkdownDescription: "**Warning:** these values are exported by the start command of the app, which is stored in the `entrypoint` user input of the app installation; anyone with access to the app installation can read them in clear text via the API or in the mittwald mStudio. They are only kept out of the Terraform state.\n\n" +
+					"    Sensitive environment variables for the app, like API tokens; requires `start_command` to be set. These are applied when `start_command` or `environment` changes, or when `sensitive_environment_version` changes, but are never stored in the state. Environment variables must not be declared in both `environment` and `sensitive_environment`.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				ElementType: types.StringType,
+			},
+			"sensitive_environment_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of the sensitive environment variables. Since the sensitive values are not stored in the state, changes to them can not be detected; increment this value to apply them.",
 				Optional:            true,
-				ElementType:         types.StringType,
 			},
 			"dependencies": schema.MapNestedAttribute{
 				MarkdownDescription: "The dependencies of the app.\n\n" +
@@ -175,6 +214,17 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 	}
 }
 
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.validateStartCommand(&resp.Diagnostics)
+}
+
 func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	r.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
 }
@@ -184,6 +234,8 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	databases := make([]DatabaseModel, 0)
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	data.injectSensitiveUserInputs(ctx, req.Config, &resp.Diagnostics)
+	data.injectSensitiveEnvironment(ctx, req.Config, &resp.Diagnostics)
 
 	// Databases may be unknown, in cases where linked database resources are determined by backend logic
 	if !data.Databases.IsUnknown() {
@@ -278,9 +330,15 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &currentData)...)
+	planData.injectSensitiveUserInputs(ctx, req.Config, &resp.Diagnostics)
+	planData.injectSensitiveEnvironment(ctx, req.Config, &resp.Diagnostics)
 
 	appClient := apiext.NewAppClient(r.client)
-	updaters := planData.ToUpdateUpdaters(ctx, resp.Diagnostics, &currentData, appClient)
+	updaters := planData.ToUpdateUpdaters(ctx, &resp.Diagnostics, &currentData, appClient)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	try := providerutil.Try[any](&resp.Diagnostics, "error while updating app installation")
 	try.Do(appClient.UpdateAppinstallation(ctx, planData.ID.ValueString(), updaters...))
@@ -350,6 +408,22 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	// don't try to reach the app while it is still being reconfigured.
 	try.Do(appClient.WaitUntilAppInstallationIsReady(ctx, planData.ID.ValueString()))
 
+	// The start command (and its environment) only takes effect once the app
+	// is restarted. The installation usually stays in the "ready" phase while
+	// restarting, so wait for the runtime status to reflect the restart first.
+	if planData.startCommandChanged(&currentData) && !resp.Diagnostics.HasError() {
+		requested := time.Now()
+
+		try.Do(appClient.RunAppInstallationAction(ctx, planData.ID.ValueString(), appv2.ActionRestart))
+		if !resp.Diagnostics.HasError() {
+			try.Do(appClient.WaitUntilAppInstallationStatus(ctx, planData.ID.ValueString(), func(status *appv2.AppInstallationStatus) bool {
+				return apiext.AppInstallationHasRestartedSince(status, requested)
+			}))
+		}
+
+		try.Do(appClient.WaitUntilAppInstallationIsReady(ctx, planData.ID.ValueString()))
+	}
+
 	resp.Diagnostics.Append(r.read(ctx, &planData)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
 }
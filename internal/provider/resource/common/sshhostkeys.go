@@ -0,0 +1,33 @@
+package common
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
+)
+
+// sshHostKeysPrivateStateKey is the private state key under which resources
+// that connect via SSH keep the host keys that were trusted on first use.
+const sshHostKeysPrivateStateKey = "ssh_host_keys"
+
+// ReadSSHHostKeyPins reads the pinned SSH host keys from the private state. A
+// missing key (for example, for resources created by an older provider
+// version) is treated as "no pinned host keys".
+func ReadSSHHostKeyPins(ctx context.Context, private PrivateStateGetter, d *diag.Diagnostics) sshutil.HostKeyPins {
+	raw, diags := private.GetKey(ctx, sshHostKeysPrivateStateKey)
+	d.Append(diags...)
+
+	pins, err := sshutil.ParseHostKeyPins(raw)
+	if err != nil {
+		d.AddError("error while reading private state", err.Error())
+		return make(sshutil.HostKeyPins)
+	}
+
+	return pins
+}
+
+// WriteSSHHostKeyPins stores the pinned SSH host keys in the private state.
+func WriteSSHHostKeyPins(ctx context.Context, private PrivateStateSetter, pins sshutil.HostKeyPins, d *diag.Diagnostics) {
+	d.Append(private.SetKey(ctx, sshHostKeysPrivateStateKey, pins.Bytes())...)
+}
@@ -0,0 +1,37 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.Int64 = &Int64AtLeastValidator{}
+
+// Int64AtLeastValidator validates that the value is at least Min.
+type Int64AtLeastValidator struct {
+	Min int64
+}
+
+func (v *Int64AtLeastValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("Validates that the value is at least %d.", v.Min)
+}
+
+func (v *Int64AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *Int64AtLeastValidator) ValidateInt64(_ context.Context, request validator.Int64Request, response *validator.Int64Response) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if value := request.ConfigValue.ValueInt64(); value < v.Min {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Value",
+			fmt.Sprintf("The value must be at least %d, got %d.", v.Min, value),
+		)
+	}
+}
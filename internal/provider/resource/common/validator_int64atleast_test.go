@@ -0,0 +1,47 @@
+package common_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+	. "github.com/onsi/gomega"
+)
+
+func TestInt64AtLeastValidator(t *testing.T) {
+	ctx := context.Background()
+
+	v := &common.Int64AtLeastValidator{Min: 1}
+
+	tests := []struct {
+		name        string
+		value       types.Int64
+		expectError bool
+	}{
+		{name: "minimum", value: types.Int64Value(1)},
+		{name: "above minimum", value: types.Int64Value(100)},
+		{name: "below minimum", value: types.Int64Value(0), expectError: true},
+		{name: "negative", value: types.Int64Value(-5), expectError: true},
+		{name: "null value should not error", value: types.Int64Null()},
+		{name: "unknown value should not error", value: types.Int64Unknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			req := validator.Int64Request{
+				Path:        path.Root("test_value"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.Int64Response{}
+
+			v.ValidateInt64(ctx, req, resp)
+
+			g.Expect(resp.Diagnostics.HasError()).To(Equal(tt.expectError))
+		})
+	}
+}
@@ -21,17 +21,19 @@ type UpdateScheduleModel struct {
 }
 
 type ContainerModel struct {
-	ID                 types.String `tfsdk:"id"`
-	ShortID            types.String `tfsdk:"short_id"`
-	Image              types.String `tfsdk:"image"`
-	Description        types.String `tfsdk:"description"`
-	Command            types.List   `tfsdk:"command"`
-	Entrypoint         types.List   `tfsdk:"entrypoint"`
-	Environment        types.Map    `tfsdk:"environment"`
-	Ports              types.Set    `tfsdk:"ports"`
-	Volumes            types.Set    `tfsdk:"volumes"`
-	Limits             types.Object `tfsdk:"limits"`
-	NoRecreateOnChange types.Bool   `tfsdk:"no_recreate_on_change"`
+	ID                       types.String `tfsdk:"id"`
+	ShortID                  types.String `tfsdk:"short_id"`
+	Image                    types.String `tfsdk:"image"`
+	Description              types.String `tfsdk:"description"`
+	Command                  types.List   `tfsdk:"command"`
+	Entrypoint               types.List   `tfsdk:"entrypoint"`
+	Environment              types.Map    `tfsdk:"environment"`
+	SecretEnvironment        types.Map    `tfsdk:"secret_environment"`
+	SecretEnvironmentVersion types.Int64  `tfsdk:"secret_environment_version"`
+	Ports                    types.Set    `tfsdk:"ports"`
+	Volumes                  types.Set    `tfsdk:"volumes"`
+	Limits                   types.Object `tfsdk:"limits"`
+	NoRecreateOnChange       types.Bool   `tfsdk:"no_recreate_on_change"`
 }
 
 type ContainerPortModel struct {
@@ -62,16 +62,18 @@ func fromAPIContainers(ctx context.Context, apiModel *containerv2.StackResponse,
 
 		state := service.PendingState
 		container := ContainerModel{
-			ID:          types.StringValue(service.Id),
-			ShortID:     types.StringValue(service.ShortId),
-			Image:       types.StringValue(image),
-			Description: types.StringValue(service.Description),
-			Command:     valueutil.ConvertStringSliceToList(state.Command),
-			Entrypoint:  valueutil.ConvertStringSliceToList(state.Entrypoint),
-			Environment: convertStringMapToMap(state.Envs),
-			Ports:       convertPortStringsToSet(ctx, state.Ports, &res),
-			Volumes:     convertVolumeStringsToSet(ctx, state.Volumes, &res),
-			Limits:      convertLimitsToObject(ctx, service.Deploy, &res),
+			ID:                       types.StringValue(service.Id),
+			ShortID:                  types.StringValue(service.ShortId),
+			Image:                    types.StringValue(image),
+			Description:              types.StringValue(service.Description),
+			Command:                  valueutil.ConvertStringSliceToList(state.Command),
+			Entrypoint:               valueutil.ConvertStringSliceToList(state.Entrypoint),
+			Environment:              convertStringMapToMap(state.Envs),
+			SecretEnvironment:        types.MapNull(types.StringType),
+			SecretEnvironmentVersion: types.Int64Null(),
+			Ports:                    convertPortStringsToSet(ctx, state.Ports, &res),
+			Volumes:                  convertVolumeStringsToSet(ctx, state.Volumes, &res),
+			Limits:                   convertLimitsToObject(ctx, service.Deploy, &res),
 		}
 
 		containerVal, diags := types.ObjectValueFrom(ctx, containerModelType.AttrTypes, container)
@@ -130,17 +132,19 @@ func fromAPIUpdateSchedule(ctx context.Context, apiModel *containerv2.StackRespo
 
 var containerModelType = types.ObjectType{
 	AttrTypes: map[string]attr.Type{
-		"id":                    types.StringType,
-		"short_id":              types.StringType,
-		"image":                 types.StringType,
-		"description":           types.StringType,
-		"command":               types.ListType{ElemType: types.StringType},
-		"entrypoint":            types.ListType{ElemType: types.StringType},
-		"environment":           types.MapType{ElemType: types.StringType},
-		"ports":                 types.SetType{ElemType: containerPortModelType},
-		"volumes":               types.SetType{ElemType: containerVolumeModelType},
-		"limits":                containerLimitsModelType,
-		"no_recreate_on_change": types.BoolType,
+		"id":                         types.StringType,
+		"short_id":                   types.StringType,
+		"image":                      types.StringType,
+		"description":                types.StringType,
+		"command":                    types.ListType{ElemType: types.StringType},
+		"entrypoint":                 types.ListType{ElemType: types.StringType},
+		"environment":                types.MapType{ElemType: types.StringType},
+		"secret_environment":         types.MapType{ElemType: types.StringType},
+		"secret_environment_version": types.Int64Type,
+		"ports":                      types.SetType{ElemType: containerPortModelType},
+		"volumes":                    types.SetType{ElemType: containerVolumeModelType},
+		"limits":                     containerLimitsModelType,
+		"no_recreate_on_change":      types.BoolType,
 	},
 }
 
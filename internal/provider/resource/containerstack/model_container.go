@@ -31,6 +31,10 @@ func (m *ContainerModel) Equals(other *ContainerModel) bool {
 		return false
 	}
 
+	if !m.SecretEnvironmentVersion.Equal(other.SecretEnvironmentVersion) {
+		return false
+	}
+
 	if !m.Ports.Equal(other.Ports) {
 		return false
 	}
@@ -51,7 +55,7 @@ func (m *ContainerModel) ToDeclareRequest(ctx context.Context, d *diag.Diagnosti
 		Image:       m.Image.ValueString(),
 		Command:     extractStringList(m.Command),
 		Entrypoint:  extractStringList(m.Entrypoint),
-		Environment: extractStringMap(m.Environment),
+		Environment: m.environmentWithSecrets(),
 		Ports:       extractPortMappings(ctx, m.Ports, d),
 		Volumes:     extractVolumeMappings(ctx, m.Volumes, d),
 		Description: m.Description.ValueStringPointer(),
@@ -74,8 +78,10 @@ func (m *ContainerModel) ToUpdateRequestFromExisting(ctx context.Context, other
 		req.Entrypoint = extractStringList(m.Entrypoint)
 	}
 
-	if !m.Environment.Equal(other.Environment) {
-		req.Envs = extractStringMap(m.Environment)
+	// The API replaces the entire environment, so the secret environment
+	// variables need to be sent along whenever any of them changes.
+	if !m.Environment.Equal(other.Environment) || !m.SecretEnvironmentVersion.Equal(other.SecretEnvironmentVersion) {
+		req.Envs = m.environmentWithSecrets()
 	}
 
 	if !m.Ports.Equal(other.Ports) {
@@ -102,10 +108,23 @@ func (m *ContainerModel) ToUpdateRequestFromEmpty(ctx context.Context, d *diag.D
 		Image:       m.Image.ValueStringPointer(),
 		Command:     extractStringList(m.Command),
 		Entrypoint:  extractStringList(m.Entrypoint),
-		Envs:        extractStringMap(m.Environment),
+		Envs:        m.environmentWithSecrets(),
 		Ports:       extractPortMappings(ctx, m.Ports, d),
 		Volumes:     extractVolumeMappings(ctx, m.Volumes, d),
 		Description: m.Description.ValueStringPointer(),
 		Deploy:      extractDeploy(ctx, m.Limits, d),
 	}
 }
+
+// environmentWithSecrets merges the regular and the secret environment
+// variables into the environment that is sent to the API. The secret
+// environment is only populated when the model was built from the
+// configuration; see injectSecretEnvironment.
+func (m *ContainerModel) environmentWithSecrets() map[string]string {
+	env := extractStringMap(m.Environment)
+	for key, value := range extractStringMap(m.SecretEnvironment) {
+		env[key] = value
+	}
+
+	return env
+}
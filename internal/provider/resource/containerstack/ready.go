@@ -3,8 +3,10 @@ package containerstackresource
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
 )
 
@@ -19,7 +21,9 @@ import (
 // follows.
 //
 // Containers that are in an error state, and any other API error, are still
-// reported as errors.
+// reported as errors. For containers in an error state, the last few lines of
+// the container's logs are attached to the diagnostic, since these usually
+// explain what went wrong.
 func waitUntilStackIsReady(ctx context.Context, client apiext.ContainerClient, stackID string, containerNames []string, timeoutHint string, d *diag.Diagnostics) {
 	err := client.WaitUntilStackIsReady(ctx, stackID, containerNames)
 	if err == nil {
@@ -37,5 +41,31 @@ func waitUntilStackIsReady(ctx context.Context, client apiext.ContainerClient, s
 		return
 	}
 
+	var serviceErr *apiext.ErrServiceInErrorState
+	if errors.As(err, &serviceErr) {
+		d.AddError("Container is in error state", serviceErrorDetail(ctx, client, serviceErr))
+		return
+	}
+
 	d.AddError("API error while waiting for stack to be ready", err.Error())
 }
+
+// serviceErrorDetail builds the detail message for a diagnostic about a
+// container in an error state, including the tail of the container's logs. Not
+// being able to retrieve the logs is not an error in itself; this is mentioned
+// in the message instead.
+func serviceErrorDetail(ctx context.Context, client apiext.ContainerClient, serviceErr *apiext.ErrServiceInErrorState) string {
+	detail := fmt.Sprintf("Container '%s' of stack %s is in an error state.", serviceErr.ServiceName, serviceErr.StackID)
+
+	logs, err := client.GetServiceLogTail(ctx, serviceErr.StackID, serviceErr.ServiceID, apiext.DefaultServiceLogTail)
+	if err != nil {
+		tflog.Warn(ctx, "could not retrieve logs of failed container", map[string]any{"error": err.Error()})
+		return detail + "\n\nThe container's logs could not be retrieved: " + err.Error()
+	}
+
+	if logs == "" {
+		return detail + "\n\nThe container did not produce any log output."
+	}
+
+	return fmt.Sprintf("%s\n\nMost recent log output of the container (up to %d lines):\n\n%s", detail, apiext.DefaultServiceLogTail, logs)
+}
@@ -53,6 +53,9 @@ func (r *Resource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *r
 			"containers": schema.MapNestedAttribute{
 				Required: true,
 				NestedObject: schema.NestedAttributeObject{
+					Validators: []validator.Object{
+						&SecretEnvironmentValidator{},
+					},
 					Attributes: map[string]schema.Attribute{
 						"id": schema.StringAttribute{
 							Computed:            true,
@@ -108,6 +111,22 @@ func (r *Resource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *r
 								mapplanmodifier.UseNonNullStateForUnknown(),
 							},
 						},
+						"secret_environment": schema.MapAttribute{
+							Optional:  true,
+							Sensitive: true,
+							WriteOnly: true,
+							MarkdownDescription: "A map of secret environment variables to set inside the container, " +
+								"such as passwords or API keys. These are sent to the API when the container is " +
+								"created or `secret_environment_version` changes, but are never stored in the state.\n\n" +
+								"    Variables must not be declared in both `environment` and `secret_environment`.",
+							ElementType: types.StringType,
+						},
+						"secret_environment_version": schema.Int64Attribute{
+							Optional: true,
+							MarkdownDescription: "Version of the secret environment variables; this is required when " +
+								"using `secret_environment`. Since the secret values are not stored in the state, " +
+								"changes to them can not be detected; increment this value to apply them.",
+						},
 						"ports": schema.SetNestedAttribute{
 							Optional:            true,
 							MarkdownDescription: "A port to expose from the container.",
@@ -43,6 +43,13 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	data.injectSecretEnvironment(ctx, req.Config, &resp.Diagnostics)
+	secretKeys := data.secretEnvironmentKeys(ctx, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	createCtx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
@@ -62,8 +69,10 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	readCtx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
 
-	resp.Diagnostics.Append(r.read(readCtx, &data, &data)...)
+	resp.Diagnostics.Append(r.read(readCtx, &data, &data, secretKeys)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	writeSecretEnvironmentKeys(ctx, resp.Private, secretKeys, &resp.Diagnostics)
 }
 
 func (r *Resource) createAsNewStack(ctx context.Context, data *ContainerStackModel, resp *resource.CreateResponse) {
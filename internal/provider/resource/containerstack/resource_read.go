@@ -25,14 +25,22 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	secretKeys := readSecretEnvironmentKeys(ctx, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	readCtx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
 
-	resp.Diagnostics.Append(r.read(readCtx, &data, &data)...)
+	resp.Diagnostics.Append(r.read(readCtx, &data, &data, secretKeys)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *Resource) read(ctx context.Context, state, plan *ContainerStackModel) (res diag.Diagnostics) {
+// read updates the state with the stack's current state in the API. The
+// secret environment variables named in secretKeys are omitted from the
+// containers' environment.
+func (r *Resource) read(ctx context.Context, state, plan *ContainerStackModel, secretKeys secretEnvironmentKeys) (res diag.Diagnostics) {
 	stack, _, err := r.client.Container().GetStack(ctx, containerclientv2.GetStackRequest{StackID: state.ID.ValueString()})
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
@@ -47,7 +55,12 @@ func (r *Resource) read(ctx context.Context, state, plan *ContainerStackModel) (
 		return
 	}
 
+	// plan and state may be the same model, so the versions need to be saved
+	// before the containers are overwritten with the API's state.
+	secretVersions := plan.secretEnvironmentVersions(ctx, &res)
+
 	res.Append(state.FromAPIModel(ctx, stack, plan, true)...)
+	state.applySecretEnvironment(ctx, secretKeys, secretVersions, &res)
 
 	return
 }
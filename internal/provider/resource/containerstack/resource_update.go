@@ -44,6 +44,13 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	// value once the state below is written from stateData.
 	stateData.Timeouts = planData.Timeouts
 
+	planData.injectSecretEnvironment(ctx, req.Config, &resp.Diagnostics)
+	secretKeys := planData.secretEnvironmentKeys(ctx, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	ctx = tflog.SetField(ctx, "stack_id", stateData.ID.ValueString())
 	client := apiext.NewContainerClient(r.client)
 
@@ -98,8 +105,10 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	readCtx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
 
-	resp.Diagnostics.Append(r.read(readCtx, &stateData, &planData)...)
+	resp.Diagnostics.Append(r.read(readCtx, &stateData, &planData, secretKeys)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &stateData)...)
+
+	writeSecretEnvironmentKeys(ctx, resp.Private, secretKeys, &resp.Diagnostics)
 }
 
 // recreateContainers checks if any containers need to be recreated based on the
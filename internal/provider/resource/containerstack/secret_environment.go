@@ -0,0 +1,157 @@
+package containerstackresource
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+)
+
+// secretEnvironmentPrivateStateKey is the private state key under which the
+// names (never the values) of each container's secret environment variables
+// are stored. These are needed on read, to tell apart secret environment
+// variables from regular ones; the API does not make that distinction.
+const secretEnvironmentPrivateStateKey = "secret_environment_keys"
+
+// secretEnvironmentKeys maps container names to the names of their secret
+// environment variables.
+type secretEnvironmentKeys map[string][]string
+
+// readSecretEnvironmentKeys reads the secret environment variable names from
+// the private state. A missing key (for example, for resources created by an
+// older provider version) is treated as "no secret environment variables".
+func readSecretEnvironmentKeys(ctx context.Context, private common.PrivateStateGetter, d *diag.Diagnostics) secretEnvironmentKeys {
+	keys := make(secretEnvironmentKeys)
+
+	raw, diags := private.GetKey(ctx, secretEnvironmentPrivateStateKey)
+	d.Append(diags...)
+
+	if len(raw) == 0 {
+		return keys
+	}
+
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		d.AddError("error while reading private state", "could not decode secret environment variable names: "+err.Error())
+	}
+
+	return keys
+}
+
+// writeSecretEnvironmentKeys stores the secret environment variable names in
+// the private state.
+func writeSecretEnvironmentKeys(ctx context.Context, private common.PrivateStateSetter, keys secretEnvironmentKeys, d *diag.Diagnostics) {
+	raw, err := json.Marshal(keys)
+	if err != nil {
+		d.AddError("error while writing private state", "could not encode secret environment variable names: "+err.Error())
+		return
+	}
+
+	d.Append(private.SetKey(ctx, secretEnvironmentPrivateStateKey, raw)...)
+}
+
+// injectSecretEnvironment copies the write-only `secret_environment` values of
+// all containers from the configuration into the model. Write-only values are
+// always null in the plan, so this needs to happen before building any API
+// request that should include them.
+func (m *ContainerStackModel) injectSecretEnvironment(ctx context.Context, config tfsdk.Config, d *diag.Diagnostics) {
+	configContainers := make(map[string]ContainerModel)
+	d.Append(config.GetAttribute(ctx, path.Root("containers"), &configContainers)...)
+
+	containers := m.ContainerModels(ctx, d)
+	if d.HasError() || containers == nil {
+		return
+	}
+
+	for name, container := range containers {
+		if configContainer, ok := configContainers[name]; ok {
+			container.SecretEnvironment = configContainer.SecretEnvironment
+			containers[name] = container
+		}
+	}
+
+	containerMap, diags := types.MapValueFrom(ctx, containerModelType, containers)
+	d.Append(diags...)
+
+	m.Containers = containerMap
+}
+
+// secretEnvironmentKeys returns the names of each container's secret
+// environment variables; see injectSecretEnvironment.
+func (m *ContainerStackModel) secretEnvironmentKeys(ctx context.Context, d *diag.Diagnostics) secretEnvironmentKeys {
+	keys := make(secretEnvironmentKeys)
+
+	for name, container := range m.ContainerModels(ctx, d) {
+		if container.SecretEnvironment.IsNull() || container.SecretEnvironment.IsUnknown() {
+			continue
+		}
+
+		names := make([]string, 0, len(container.SecretEnvironment.Elements()))
+		for key := range container.SecretEnvironment.Elements() {
+			names = append(names, key)
+		}
+
+		sort.Strings(names)
+		keys[name] = names
+	}
+
+	return keys
+}
+
+// secretEnvironmentVersions returns each container's
+// `secret_environment_version`.
+func (m *ContainerStackModel) secretEnvironmentVersions(ctx context.Context, d *diag.Diagnostics) map[string]types.Int64 {
+	versions := make(map[string]types.Int64)
+
+	for name, container := range m.ContainerModels(ctx, d) {
+		versions[name] = container.SecretEnvironmentVersion
+	}
+
+	return versions
+}
+
+// applySecretEnvironment post-processes a model that was read from the API:
+// The secret environment variables are removed from each container's
+// `environment` (so that their values never end up in the state), and the
+// `secret_environment_version` is carried over from the given versions, since
+// it is not known to the API.
+func (m *ContainerStackModel) applySecretEnvironment(ctx context.Context, keys secretEnvironmentKeys, versions map[string]types.Int64, d *diag.Diagnostics) {
+	containers := m.ContainerModels(ctx, d)
+	if d.HasError() || containers == nil {
+		return
+	}
+
+	for name, container := range containers {
+		if version, ok := versions[name]; ok {
+			container.SecretEnvironmentVersion = version
+		}
+
+		if secretKeys := keys[name]; len(secretKeys) > 0 && !container.Environment.IsNull() {
+			env := make(map[string]attr.Value, len(container.Environment.Elements()))
+			for key, value := range container.Environment.Elements() {
+				env[key] = value
+			}
+
+			for _, key := range secretKeys {
+				delete(env, key)
+			}
+
+			environment, diags := types.MapValue(types.StringType, env)
+			d.Append(diags...)
+
+			container.Environment = environment
+		}
+
+		containers[name] = container
+	}
+
+	containerMap, diags := types.MapValueFrom(ctx, containerModelType, containers)
+	d.Append(diags...)
+
+	m.Containers = containerMap
+}
@@ -0,0 +1,56 @@
+package containerstackresource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ validator.Object = &SecretEnvironmentValidator{}
+
+// SecretEnvironmentValidator is a validator that asserts that
+// secret_environment_version is set when using secret_environment, and that no
+// variable is declared in both environment and secret_environment.
+type SecretEnvironmentValidator struct{}
+
+func (v *SecretEnvironmentValidator) Description(_ context.Context) string {
+	return "Asserts that secret_environment_version is set when using secret_environment, and that environment and secret_environment do not overlap."
+}
+
+func (v *SecretEnvironmentValidator) MarkdownDescription(_ context.Context) string {
+	return "Asserts that `secret_environment_version` is set when using `secret_environment`, and that `environment` and `secret_environment` do not overlap."
+}
+
+func (v *SecretEnvironmentValidator) ValidateObject(_ context.Context, request validator.ObjectRequest, response *validator.ObjectResponse) {
+	attrs := request.ConfigValue.Attributes()
+
+	secretEnvironment, ok := attrs["secret_environment"].(types.Map)
+	if !ok || secretEnvironment.IsNull() || secretEnvironment.IsUnknown() {
+		return
+	}
+
+	if version, ok := attrs["secret_environment_version"].(types.Int64); !ok || version.IsNull() {
+		response.Diagnostics.AddAttributeError(
+			request.Path.AtName("secret_environment_version"),
+			"missing secret_environment_version",
+			"secret_environment_version is required when using secret_environment",
+		)
+	}
+
+	environment, ok := attrs["environment"].(types.Map)
+	if !ok || environment.IsNull() || environment.IsUnknown() {
+		return
+	}
+
+	for key := range secretEnvironment.Elements() {
+		if _, duplicate := environment.Elements()[key]; duplicate {
+			response.Diagnostics.AddAttributeError(
+				request.Path.AtName("secret_environment").AtMapKey(key),
+				"duplicate environment variable",
+				fmt.Sprintf("environment variable %s is declared in both environment and secret_environment", key),
+			)
+		}
+	}
+}
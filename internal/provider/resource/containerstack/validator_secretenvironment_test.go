@@ -0,0 +1,98 @@
+package containerstackresource_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	containerstackresource "github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/containerstack"
+	. "github.com/onsi/gomega"
+)
+
+func TestSecretEnvironmentValidator(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	v := &containerstackresource.SecretEnvironmentValidator{}
+
+	attrTypes := map[string]attr.Type{
+		"environment":                types.MapType{ElemType: types.StringType},
+		"secret_environment":         types.MapType{ElemType: types.StringType},
+		"secret_environment_version": types.Int64Type,
+	}
+
+	stringMap := func(values map[string]string) types.Map {
+		elements := make(map[string]attr.Value, len(values))
+		for k, v := range values {
+			elements[k] = types.StringValue(v)
+		}
+		return types.MapValueMust(types.StringType, elements)
+	}
+
+	tests := []struct {
+		name        string
+		environment types.Map
+		secrets     types.Map
+		version     types.Int64
+		expectError bool
+	}{
+		{
+			name:        "no secret environment",
+			environment: stringMap(map[string]string{"FOO": "bar"}),
+			secrets:     types.MapNull(types.StringType),
+			version:     types.Int64Null(),
+			expectError: false,
+		},
+		{
+			name:        "secret environment with version",
+			environment: stringMap(map[string]string{"FOO": "bar"}),
+			secrets:     stringMap(map[string]string{"DB_PASSWORD": "secret"}),
+			version:     types.Int64Value(1),
+			expectError: false,
+		},
+		{
+			name:        "secret environment without version",
+			environment: types.MapNull(types.StringType),
+			secrets:     stringMap(map[string]string{"DB_PASSWORD": "secret"}),
+			version:     types.Int64Null(),
+			expectError: true,
+		},
+		{
+			name:        "variable declared in both maps",
+			environment: stringMap(map[string]string{"DB_PASSWORD": "plain"}),
+			secrets:     stringMap(map[string]string{"DB_PASSWORD": "secret"}),
+			version:     types.Int64Value(1),
+			expectError: true,
+		},
+		{
+			name:        "unknown secret environment is not validated",
+			environment: stringMap(map[string]string{"DB_PASSWORD": "plain"}),
+			secrets:     types.MapUnknown(types.StringType),
+			version:     types.Int64Null(),
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"environment":                tt.environment,
+				"secret_environment":         tt.secrets,
+				"secret_environment_version": tt.version,
+			})
+
+			req := validator.ObjectRequest{
+				Path:        path.Root("containers").AtMapKey("app"),
+				ConfigValue: value,
+			}
+			resp := &validator.ObjectResponse{}
+
+			v.ValidateObject(ctx, req, resp)
+
+			g.Expect(resp.Diagnostics.HasError()).To(Equal(tt.expectError))
+		})
+	}
+}
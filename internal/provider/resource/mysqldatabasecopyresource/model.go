@@ -0,0 +1,48 @@
+package mysqldatabasecopyresource
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ResourceModel describes the resource data model.
+type ResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	SourceDatabaseID types.String `tfsdk:"source_database_id"`
+	ProjectID        types.String `tfsdk:"project_id"`
+	Version          types.String `tfsdk:"version"`
+	Name             types.String `tfsdk:"name"`
+	Description      types.String `tfsdk:"description"`
+	Hostname         types.String `tfsdk:"hostname"`
+
+	CharacterSettings types.Object `tfsdk:"character_settings"`
+	User              types.Object `tfsdk:"user"`
+}
+
+type UserModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+	AccessLevel       types.String `tfsdk:"access_level"`
+	ExternalAccess    types.Bool   `tfsdk:"external_access"`
+}
+
+type CharsetModel struct {
+	Charset   types.String `tfsdk:"character_set"`
+	Collation types.String `tfsdk:"collation"`
+}
+
+var charsetAttrs = map[string]attr.Type{
+	"character_set": types.StringType,
+	"collation":     types.StringType,
+}
+
+var userAttrs = map[string]attr.Type{
+	"id":                  types.StringType,
+	"name":                types.StringType,
+	"password_wo":         types.StringType,
+	"password_wo_version": types.Int64Type,
+	"access_level":        types.StringType,
+	"external_access":     types.BoolType,
+}
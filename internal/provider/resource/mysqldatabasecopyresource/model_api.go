@@ -0,0 +1,97 @@
+package mysqldatabasecopyresource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/databaseclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/databasev2"
+)
+
+func (m *ResourceModel) ToCreateRequest(user *UserModel, password string) databaseclientv2.CopyMysqlDatabaseRequest {
+	return databaseclientv2.CopyMysqlDatabaseRequest{
+		MysqlDatabaseID: m.SourceDatabaseID.ValueString(),
+		Body: databaseclientv2.CopyMysqlDatabaseRequestBody{
+			Description: m.Description.ValueString(),
+			User: databasev2.CreateMySqlUserWithDatabase{
+				Password:    password,
+				AccessLevel: databasev2.CreateMySqlUserWithDatabaseAccessLevel(user.AccessLevel.ValueString()),
+			},
+		},
+	}
+}
+
+func (m *ResourceModel) ToDeleteRequest() databaseclientv2.DeleteMysqlDatabaseRequest {
+	return databaseclientv2.DeleteMysqlDatabaseRequest{
+		MysqlDatabaseID: m.ID.ValueString(),
+	}
+}
+
+func (m *ResourceModel) FromAPIModel(ctx context.Context, apiDatabase *databasev2.MySqlDatabase, apiUser *databasev2.MySqlUser) (res diag.Diagnostics) {
+	user := UserModel{}
+	if !m.User.IsNull() && !m.User.IsUnknown() {
+		res.Append(m.User.As(ctx, &user, basetypes.ObjectAsOptions{})...)
+	}
+
+	m.Name = types.StringValue(apiDatabase.Name)
+	m.Hostname = types.StringValue(apiDatabase.Hostname)
+	m.Description = types.StringValue(apiDatabase.Description)
+	m.Version = types.StringValue(apiDatabase.Version)
+	m.ProjectID = types.StringValue(apiDatabase.ProjectId)
+
+	charset := CharsetModel{
+		Charset:   types.StringValue(apiDatabase.CharacterSettings.CharacterSet),
+		Collation: types.StringValue(apiDatabase.CharacterSettings.Collation),
+	}
+
+	m.CharacterSettings = charset.AsObject(ctx, &res)
+
+	user.ID = types.StringValue(apiUser.Id)
+	user.Name = types.StringValue(apiUser.Name)
+	user.AccessLevel = types.StringValue(string(apiUser.AccessLevel))
+	user.ExternalAccess = types.BoolValue(apiUser.ExternalAccess)
+	user.PasswordWO = types.StringNull()
+
+	m.User = user.AsObject(ctx, &res)
+
+	return
+}
+
+func (m *CharsetModel) AsObject(ctx context.Context, d *diag.Diagnostics) types.Object {
+	val, diags := types.ObjectValueFrom(ctx, charsetAttrs, m)
+	d.Append(diags...)
+
+	return val
+}
+
+func (m *CharsetModel) ToAPIModel() *databasev2.CharacterSettings {
+	return &databasev2.CharacterSettings{
+		CharacterSet: m.Charset.ValueString(),
+		Collation:    m.Collation.ValueString(),
+	}
+}
+
+func (m *UserModel) AsObject(ctx context.Context, d *diag.Diagnostics) types.Object {
+	val, diags := types.ObjectValueFrom(ctx, userAttrs, m)
+	d.Append(diags...)
+
+	return val
+}
+
+// partialState returns the user as it is stored in the state before the
+// database copy is ready: only the attributes that are already known are set,
+// the computed ones are filled in when the copy is read.
+func (m *UserModel) partialState(ctx context.Context, d *diag.Diagnostics) types.Object {
+	partial := UserModel{
+		ID:                m.ID,
+		Name:              types.StringNull(),
+		PasswordWO:        types.StringNull(),
+		PasswordWOVersion: m.PasswordWOVersion,
+		AccessLevel:       m.AccessLevel,
+		ExternalAccess:    types.BoolNull(),
+	}
+
+	return partial.AsObject(ctx, d)
+}
@@ -0,0 +1,384 @@
+package mysqldatabasecopyresource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/databaseclientv2"
+	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/databasev2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &Resource{}
+
+// copyTimeout is the maximum duration of copying the contents of the source
+// database. Copies usually complete within minutes, depending on the size of
+// the source database; the timeout is only meant to keep a stuck copy from
+// blocking the apply indefinitely.
+const copyTimeout = 1 * time.Hour
+
+func New() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client mittwaldv2.Client
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mysql_database_copy"
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	builder := common.AttributeBuilderFor("database copy")
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: description,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Models a copy of an existing MySQL database, for example for a preview environment.\n\n" +
+			"The copy is created in the same project as the source database, with its own database user. It is " +
+			"created from the current contents of the source database, and is not kept in sync with it afterwards. " +
+			"Destroying this resource deletes only the copy; the source database is not affected.",
+		Attributes: map[string]schema.Attribute{
+			"id": builder.Id(),
+			"source_database_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the MySQL database to copy. Must be a full UUID.",
+				Required:            true,
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_id":  computed("The ID of the project the database copy belongs to; this is always the project of the source database"),
+			"description": builder.Description(),
+			"version":     computed("Version of the database copy, e.g. `8.0`; this is always the version of the source database"),
+			"name":        computed("Name of the database copy, e.g. `db-XXXXX`"),
+			"hostname":    computed("Hostname of the database copy; this is the hostname that you should use within the platform to connect to the database."),
+			"character_settings": schema.SingleNestedAttribute{
+				MarkdownDescription: "Default character settings of the database copy; if not set, the character settings of the source database are kept.\n\n" +
+					"    These only set the defaults for tables that are created afterwards. Tables that were copied from the source " +
+					"database keep their character set and collation; changing this attribute does not convert them.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"collation": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Default collation of the database copy, e.g. `utf8mb4_general_ci`",
+					},
+					"character_set": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Default character set of the database copy, e.g. `utf8mb4`",
+					},
+				},
+			},
+			"user": schema.SingleNestedAttribute{
+				MarkdownDescription: "The main database user of the database copy",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"id":   computed("ID of the database user"),
+					"name": computed("Name of the database user, e.g. `dbu-XXXXX`"),
+					"password_wo": schema.StringAttribute{
+						Required:  true,
+						Sensitive: true,
+						WriteOnly: true,
+						MarkdownDescription: "Password for the database user. The password is not stored in the state, but only used " +
+							"to create the user. You can use the `mittwald_mysql_password` ephemeral resource to dynamically generate " +
+							"a valid password.",
+					},
+					"password_wo_version": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Version of the password for the database user; increment this to update the password of an existing database copy.",
+					},
+					"access_level": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Access level for the database user, e.g. `full` or `readonly`",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"external_access": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the database user is accessible from outside the cluster",
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	data := ResourceModel{}
+	user := UserModel{}
+	password := types.String{}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(data.User.As(ctx, &user, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("user").AtName("password_wo"), &password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.Database()
+
+	copyRes := providerutil.
+		Try[*databaseclientv2.CopyMysqlDatabaseResponse](&resp.Diagnostics, "error while copying database").
+		DoValResp(client.CopyMysqlDatabase(ctx, data.ToCreateRequest(&user, password.ValueString())))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(copyRes.Id)
+	user.ID = types.StringValue(copyRes.UserId)
+	data.User = user.AsObject(ctx, &resp.Diagnostics)
+
+	// Store the ID right away, so that the copy is tainted (instead of
+	// orphaned) if any of the following steps fail.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), data.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_database_id"), data.SourceDatabaseID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user"), user.partialState(ctx, &resp.Diagnostics))...)
+
+	resp.Diagnostics.Append(r.waitUntilReady(ctx, data.ID.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.CharacterSettings.IsUnknown() && !data.CharacterSettings.IsNull() {
+		r.updateCharset(ctx, &data, &resp.Diagnostics)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, diags := r.read(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if !found && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError("error while reading database copy", "the database copy was created, but could not be read back")
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// waitUntilReady polls the database until it has finished copying the
+// contents of the source database, for at most copyTimeout.
+func (r *Resource) waitUntilReady(ctx context.Context, databaseID string) (res diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(ctx, copyTimeout)
+	defer cancel()
+
+	if _, err := apiext.WaitUntilMySQLDatabaseIsReady(ctx, r.client.Database(), databaseID, nil); err != nil {
+		res.AddError("error while waiting for database copy to become ready", fmt.Sprintf("The database was copied, but the copy did not become ready: %s", err))
+	}
+
+	return
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	data := ResourceModel{}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, diags := r.read(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// read refreshes the given model from the API. It reports whether the
+// database copy still exists.
+func (r *Resource) read(ctx context.Context, data *ResourceModel) (bool, diag.Diagnostics) {
+	var res diag.Diagnostics
+
+	client := r.client.Database()
+	user := UserModel{}
+
+	// The user may be missing from the state (for example, after a failed
+	// create); in that case, it is looked up by the database.
+	if !data.User.IsNull() && !data.User.IsUnknown() {
+		res.Append(data.User.As(ctx, &user, basetypes.ObjectAsOptions{})...)
+		if res.HasError() {
+			return false, res
+		}
+	}
+
+	database := providerutil.
+		Try[*databasev2.MySqlDatabase](&res, "error while reading database copy").
+		IgnoreNotFound().
+		DoValResp(client.GetMysqlDatabase(ctx, databaseclientv2.GetMysqlDatabaseRequest{MysqlDatabaseID: data.ID.ValueString()}))
+
+	if res.HasError() || database == nil {
+		return false, res
+	}
+
+	databaseUser := providerutil.
+		Try[*databasev2.MySqlUser](&res, "error while reading database user").
+		DoVal(r.findDatabaseUser(ctx, data.ID.ValueString(), &user))
+
+	if res.HasError() {
+		return false, res
+	}
+
+	res.Append(data.FromAPIModel(ctx, database, databaseUser)...)
+
+	return true, res
+}
+
+// findDatabaseUser looks up the user of the database copy by its ID, or, if
+// the ID is not known, by checking which user is the main user of the database.
+func (r *Resource) findDatabaseUser(ctx context.Context, databaseID string, user *UserModel) (*databasev2.MySqlUser, error) {
+	client := r.client.Database()
+
+	if !user.ID.IsNull() && !user.ID.IsUnknown() {
+		databaseUser, _, err := client.GetMysqlUser(ctx, databaseclientv2.GetMysqlUserRequest{MysqlUserID: user.ID.ValueString()})
+		return databaseUser, err
+	}
+
+	databaseUsers, _, err := client.ListMysqlUsers(ctx, databaseclientv2.ListMysqlUsersRequest{MysqlDatabaseID: databaseID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, databaseUser := range *databaseUsers {
+		if databaseUser.MainUser {
+			user.ID = types.StringValue(databaseUser.Id)
+			return &databaseUser, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not find main user for database %s", databaseID)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	planData := ResourceModel{}
+	stateData := ResourceModel{}
+	planUser := UserModel{}
+	stateUser := UserModel{}
+	password := types.String{}
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	resp.Diagnostics.Append(planData.User.As(ctx, &planUser, basetypes.ObjectAsOptions{})...)
+	resp.Diagnostics.Append(stateData.User.As(ctx, &stateUser, basetypes.ObjectAsOptions{})...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("user").AtName("password_wo"), &password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.Database()
+
+	if !planData.Description.Equal(stateData.Description) {
+		providerutil.
+			Try[any](&resp.Diagnostics, "error while updating database copy").
+			DoResp(client.PatchMysqlDatabase(ctx, databaseclientv2.PatchMysqlDatabaseRequest{
+				MysqlDatabaseID: planData.ID.ValueString(),
+				Body: databaseclientv2.PatchMysqlDatabaseRequestBody{
+					Description: planData.Description.ValueStringPointer(),
+				},
+			}))
+	}
+
+	if !planData.CharacterSettings.Equal(stateData.CharacterSettings) {
+		r.updateCharset(ctx, &planData, &resp.Diagnostics)
+	}
+
+	if !planUser.PasswordWOVersion.Equal(stateUser.PasswordWOVersion) {
+		pw := password.ValueString()
+
+		providerutil.
+			Try[any](&resp.Diagnostics, "error while setting database user password").
+			DoResp(client.UpdateMysqlUser(ctx, databaseclientv2.UpdateMysqlUserRequest{
+				MysqlUserID: stateUser.ID.ValueString(),
+				Body: databaseclientv2.UpdateMysqlUserRequestBody{
+					Password: &pw,
+				},
+			}))
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := r.read(ctx, &planData)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *Resource) updateCharset(ctx context.Context, data *ResourceModel, d *diag.Diagnostics) {
+	charset := CharsetModel{}
+
+	d.Append(data.CharacterSettings.As(ctx, &charset, basetypes.ObjectAsOptions{})...)
+	if d.HasError() {
+		return
+	}
+
+	providerutil.
+		Try[any](d, "error while updating character settings of database copy").
+		DoResp(r.client.Database().PatchMysqlDatabase(ctx, databaseclientv2.PatchMysqlDatabaseRequest{
+			MysqlDatabaseID: data.ID.ValueString(),
+			Body: databaseclientv2.PatchMysqlDatabaseRequestBody{
+				CharacterSettings: charset.ToAPIModel(),
+			},
+		}))
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerutil.
+		Try[any](&resp.Diagnostics, "error while deleting database copy").
+		IgnoreNotFound().
+		DoResp(r.client.Database().DeleteMysqlDatabase(ctx, data.ToDeleteRequest()))
+}
@@ -16,6 +16,7 @@ import (
 	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
 	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/databaseclientv2"
 	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/databasev2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
 	"github.com/mittwald/terraform-provider-mittwald/internal/apiutils"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
@@ -25,6 +26,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
 
 func New() resource.Resource {
 	return &Resource{}
@@ -47,10 +49,7 @@ func (d *Resource) Schema(_ context.Context, _ resource.SchemaRequest, response
 			"id": builder.Id(),
 			"version": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Version of the database, e.g. `5.7`",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				MarkdownDescription: "Version of the database, e.g. `5.7`; changing the version upgrades the database in-place, which fails if the database enters an error state or the upgrade does not complete within 30 minutes. Downgrades are not supported.",
 			},
 			"name": schema.StringAttribute{
 				Computed:            true,
@@ -251,6 +250,7 @@ func (d *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	d.updateVersion(ctx, &planData, &stateData, resp)
 	d.updateCharset(ctx, planData.ID.ValueString(), &planCharset, &stateCharset, resp)
 	d.updateDescription(ctx, &planData, &stateData, resp)
 	d.updatePasswordDeprecated(ctx, &planUser, resp)
@@ -274,6 +274,64 @@ func (d *Resource) unpack(ctx context.Context, planOrState interface {
 	return
 }
 
+func (d *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var planVersion, stateVersion types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("version"), &planVersion)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version"), &stateVersion)...)
+
+	if resp.Diagnostics.HasError() || planVersion.IsUnknown() || stateVersion.IsNull() {
+		return
+	}
+
+	if err := checkVersionChange(stateVersion.ValueString(), planVersion.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("version"), "Unsupported version change", err.Error())
+	}
+}
+
+func (d *Resource) updateVersion(ctx context.Context, planData, stateData *ResourceModel, resp *resource.UpdateResponse) {
+	if planData.Version.Equal(stateData.Version) {
+		return
+	}
+
+	client := d.client.Database()
+	version := planData.Version.ValueString()
+
+	providerutil.
+		Try[any](&resp.Diagnostics, "error while upgrading database").
+		DoResp(client.PatchMysqlDatabase(ctx, databaseclientv2.PatchMysqlDatabaseRequest{
+			MysqlDatabaseID: planData.ID.ValueString(),
+			Body: databaseclientv2.PatchMysqlDatabaseRequestBody{
+				Version: &version,
+			},
+		}))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "waiting for database upgrade to complete", map[string]any{"version": version})
+
+	waitCtx, cancel := context.WithTimeout(ctx, versionUpgradeTimeout)
+	defer cancel()
+
+	// The upgrade is performed asynchronously; the database is unavailable
+	// until it reports the new version and becomes ready again.
+	_, err := apiext.WaitUntilMySQLDatabaseIsReady(waitCtx, client, planData.ID.ValueString(), func(database *databasev2.MySqlDatabase) bool {
+		return database.Version == version
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error while waiting for database upgrade to complete",
+			fmt.Sprintf("The upgrade to version %s was started, but did not complete: %s", version, err),
+		)
+	}
+}
+
 func (d *Resource) updateCharset(ctx context.Context, databaseID string, planData, stateData *MySQLDatabaseCharsetModel, resp *resource.UpdateResponse) {
 	if planData.AsObject(ctx, resp.Diagnostics).Equal(stateData.AsObject(ctx, resp.Diagnostics)) {
 		return
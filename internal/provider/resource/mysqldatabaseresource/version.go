@@ -0,0 +1,39 @@
+package mysqldatabaseresource
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// versionUpgradeTimeout is the maximum duration of an in-place version
+// upgrade. Upgrades usually take a few minutes, depending on the size of the
+// database; the timeout is only meant to keep a stuck upgrade from blocking
+// the apply indefinitely.
+const versionUpgradeTimeout = 30 * time.Minute
+
+// checkVersionChange verifies that a database can be changed from the current
+// to the planned version in-place; MySQL only supports upgrades, so any
+// downgrade is rejected.
+func checkVersionChange(current, planned string) error {
+	if current == planned {
+		return nil
+	}
+
+	currentVersion, err := semver.NewVersion(current)
+	if err != nil {
+		return fmt.Errorf("could not parse current version %q: %w", current, err)
+	}
+
+	plannedVersion, err := semver.NewVersion(planned)
+	if err != nil {
+		return fmt.Errorf("could not parse planned version %q: %w", planned, err)
+	}
+
+	if plannedVersion.LessThan(currentVersion) {
+		return fmt.Errorf("the database is currently running MySQL %s; downgrading to %s is not supported. To downgrade, dump the database, then recreate it with the desired version (for example using `terraform apply -replace`) and import the dump", current, planned)
+	}
+
+	return nil
+}
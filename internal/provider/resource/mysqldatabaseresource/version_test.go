@@ -0,0 +1,17 @@
+package mysqldatabaseresource
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCheckVersionChange(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(checkVersionChange("8.0", "8.0")).To(Succeed())
+	g.Expect(checkVersionChange("5.7", "8.0")).To(Succeed())
+	g.Expect(checkVersionChange("8.0", "8.4")).To(Succeed())
+	g.Expect(checkVersionChange("8.0", "5.7")).To(MatchError(ContainSubstring("not supported")))
+	g.Expect(checkVersionChange("8.0", "latest")).To(MatchError(ContainSubstring("could not parse")))
+}
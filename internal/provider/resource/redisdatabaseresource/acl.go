@@ -0,0 +1,109 @@
+package redisdatabaseresource
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// aclUserFlagPrefix is the prefix of the server flags that configure Redis ACL
+// users; see https://redis.io/docs/latest/operate/oss_and_stack/management/security/acl/
+// for the rule syntax.
+const aclUserFlagPrefix = "--user"
+
+// aclUser is a Redis ACL user, configured through a "--user" server flag.
+// Passwords are only ever passed as SHA-256 hashes, so they do not show up in
+// the database configuration in plain text.
+type aclUser struct {
+	Name         string
+	PasswordHash string
+	Keys         []string
+	Channels     []string
+	Commands     []string
+}
+
+func hashACLPassword(password string) string {
+	sum := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(sum[:])
+}
+
+// flag renders the user as a server flag. The "reset" rule makes sure that
+// the flag fully describes the user, regardless of the Redis defaults; since
+// it also implies "resetchannels", Pub/Sub channels must be granted
+// explicitly.
+func (u *aclUser) flag() string {
+	rules := []string{aclUserFlagPrefix, u.Name, "reset", "on", "#" + u.PasswordHash}
+
+	for _, key := range u.Keys {
+		rules = append(rules, "~"+key)
+	}
+
+	for _, channel := range u.Channels {
+		rules = append(rules, "&"+channel)
+	}
+
+	rules = append(rules, u.Commands...)
+
+	return strings.Join(rules, " ")
+}
+
+// validate checks that the user can be expressed as a server flag; since
+// rules are separated by whitespace, none of them may contain any.
+func (u *aclUser) validate() error {
+	if u.Name == "" || strings.ContainsAny(u.Name, " \t\n") {
+		return fmt.Errorf("user name %q must not be empty or contain whitespace", u.Name)
+	}
+
+	for _, key := range u.Keys {
+		if key == "" || strings.ContainsAny(key, " \t\n") {
+			return fmt.Errorf("key pattern %q must not be empty or contain whitespace", key)
+		}
+	}
+
+	for _, channel := range u.Channels {
+		if channel == "" || strings.ContainsAny(channel, " \t\n") {
+			return fmt.Errorf("channel pattern %q must not be empty or contain whitespace", channel)
+		}
+	}
+
+	for _, command := range u.Commands {
+		if !strings.HasPrefix(command, "+") && !strings.HasPrefix(command, "-") {
+			return fmt.Errorf("command rule %q must start with + or -", command)
+		}
+
+		if strings.ContainsAny(command, " \t\n") {
+			return fmt.Errorf("command rule %q must not contain whitespace", command)
+		}
+	}
+
+	return nil
+}
+
+// parseACLUserFlag parses a server flag that was rendered by aclUser.flag.
+// Flags that were not rendered by this provider (like "--user" flags with
+// plain-text passwords) are not recognized, and will be treated as regular
+// additional flags instead.
+func parseACLUserFlag(flag string) (*aclUser, bool) {
+	fields := strings.Fields(flag)
+	if len(fields) < 5 || fields[0] != aclUserFlagPrefix || fields[2] != "reset" || fields[3] != "on" || !strings.HasPrefix(fields[4], "#") {
+		return nil, false
+	}
+
+	user := aclUser{Name: fields[1], PasswordHash: strings.TrimPrefix(fields[4], "#")}
+
+	for _, rule := range fields[5:] {
+		switch {
+		case strings.HasPrefix(rule, "~"):
+			user.Keys = append(user.Keys, strings.TrimPrefix(rule, "~"))
+		case strings.HasPrefix(rule, "&"):
+			user.Channels = append(user.Channels, strings.TrimPrefix(rule, "&"))
+		case strings.HasPrefix(rule, "+"), strings.HasPrefix(rule, "-"):
+			user.Commands = append(user.Commands, rule)
+		default:
+			return nil, false
+		}
+	}
+
+	return &user, true
+}
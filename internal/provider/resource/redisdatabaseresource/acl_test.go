@@ -0,0 +1,52 @@
+package redisdatabaseresource
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestACLUserFlagRoundTrip(t *testing.T) {
+	g := NewWithT(t)
+
+	user := aclUser{
+		Name:         "app",
+		PasswordHash: hashACLPassword("secret"),
+		Keys:         []string{"app:*", "cache:*"},
+		Channels:     []string{"events:*"},
+		Commands:     []string{"+@read", "+@write", "-flushall"},
+	}
+
+	flag := user.flag()
+	g.Expect(flag).To(Equal("--user app reset on #" + hashACLPassword("secret") + " ~app:* ~cache:* &events:* +@read +@write -flushall"))
+	g.Expect(flag).NotTo(ContainSubstring("secret "))
+
+	parsed, ok := parseACLUserFlag(flag)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(*parsed).To(Equal(user))
+}
+
+func TestParseACLUserFlagIgnoresForeignFlags(t *testing.T) {
+	for _, flag := range []string{
+		"--maxclients 100",
+		"--user app on >plaintext ~* +@all",
+		"--user app reset on #abc ~* allcommands",
+	} {
+		t.Run(flag, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, ok := parseACLUserFlag(flag)
+			g.Expect(ok).To(BeFalse())
+		})
+	}
+}
+
+func TestACLUserValidate(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect((&aclUser{Name: "app", Keys: []string{"*"}, Channels: []string{"*"}, Commands: []string{"+@all"}}).validate()).To(Succeed())
+	g.Expect((&aclUser{Name: "my app"}).validate()).To(HaveOccurred())
+	g.Expect((&aclUser{Name: "app", Keys: []string{"a b"}}).validate()).To(HaveOccurred())
+	g.Expect((&aclUser{Name: "app", Channels: []string{""}}).validate()).To(HaveOccurred())
+	g.Expect((&aclUser{Name: "app", Commands: []string{"@read"}}).validate()).To(HaveOccurred())
+}
@@ -12,6 +12,7 @@ type ResourceModel struct {
 	Hostname    types.String `tfsdk:"hostname"`
 
 	Configuration types.Object `tfsdk:"configuration"`
+	Users         types.Map    `tfsdk:"users"`
 }
 
 type RedisConfigurationModel struct {
@@ -20,3 +21,11 @@ type RedisConfigurationModel struct {
 	MaxMemoryPolicy types.String `tfsdk:"max_memory_policy"`
 	Persistent      types.Bool   `tfsdk:"persistent"`
 }
+
+type RedisUserModel struct {
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+	Keys              types.List   `tfsdk:"keys"`
+	Channels          types.List   `tfsdk:"channels"`
+	Commands          types.List   `tfsdk:"commands"`
+}
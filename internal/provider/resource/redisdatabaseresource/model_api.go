@@ -4,44 +4,109 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/databaseclientv2"
 	"github.com/mittwald/api-client-go/mittwaldv2/generated/schemas/databasev2"
 	"github.com/mittwald/terraform-provider-mittwald/internal/valueutil"
+	"maps"
+	"slices"
 )
 
-func (m *ResourceModel) ToCreateRequest(ctx context.Context, d *diag.Diagnostics) databaseclientv2.CreateRedisDatabaseRequest {
+func (m *ResourceModel) ToCreateRequest(ctx context.Context, users []aclUser, d *diag.Diagnostics) databaseclientv2.CreateRedisDatabaseRequest {
 	return databaseclientv2.CreateRedisDatabaseRequest{
 		ProjectID: m.ProjectID.ValueString(),
 		Body: databaseclientv2.CreateRedisDatabaseRequestBody{
 			Description:   m.Description.ValueString(),
 			Version:       m.Version.ValueString(),
-			Configuration: m.mapConfiguration(ctx, d),
+			Configuration: m.mapConfiguration(ctx, users, d),
 		},
 	}
 }
 
-func (m *ResourceModel) mapConfiguration(ctx context.Context, d *diag.Diagnostics) *databasev2.RedisDatabaseConfiguration {
+// mapConfiguration maps the configuration into the API model; the given ACL
+// users (see aclUsers) are added as server flags.
+func (m *ResourceModel) mapConfiguration(ctx context.Context, users []aclUser, d *diag.Diagnostics) *databasev2.RedisDatabaseConfiguration {
 	configurationModel := RedisConfigurationModel{}
 
-	d.Append(m.Configuration.As(ctx, &configurationModel, basetypes.ObjectAsOptions{})...)
+	if !m.Configuration.IsNull() && !m.Configuration.IsUnknown() {
+		d.Append(m.Configuration.As(ctx, &configurationModel, basetypes.ObjectAsOptions{})...)
+	}
 
 	additionalFlags := make([]string, 0, 8)
 	for _, v := range configurationModel.AdditionalFlags.Elements() {
-		if str, ok := v.(basetypes.StringValue); ok {
+		if str, ok := v.(basetypes.StringValue); ok && !str.IsUnknown() {
 			additionalFlags = append(additionalFlags, str.ValueString())
 		}
 	}
 
-	maxMemory := fmt.Sprintf("%dMi", configurationModel.MaxMemoryMB.ValueInt64())
+	for _, user := range users {
+		additionalFlags = append(additionalFlags, user.flag())
+	}
 
-	return &databasev2.RedisDatabaseConfiguration{
+	configuration := databasev2.RedisDatabaseConfiguration{
 		AdditionalFlags: additionalFlags,
-		MaxMemory:       &maxMemory,
-		MaxMemoryPolicy: configurationModel.MaxMemoryPolicy.ValueStringPointer(),
-		Persistent:      configurationModel.Persistent.ValueBoolPointer(),
 	}
+
+	// Computed values that are not known yet are omitted, so that the platform
+	// keeps (or chooses) its own values for them.
+	if !configurationModel.MaxMemoryMB.IsNull() && !configurationModel.MaxMemoryMB.IsUnknown() {
+		maxMemory := fmt.Sprintf("%dMi", configurationModel.MaxMemoryMB.ValueInt64())
+		configuration.MaxMemory = &maxMemory
+	}
+
+	if !configurationModel.MaxMemoryPolicy.IsUnknown() {
+		configuration.MaxMemoryPolicy = configurationModel.MaxMemoryPolicy.ValueStringPointer()
+	}
+
+	if !configurationModel.Persistent.IsUnknown() {
+		configuration.Persistent = configurationModel.Persistent.ValueBoolPointer()
+	}
+
+	return &configuration
+}
+
+// aclUsers returns the configured ACL users, ordered by name, with the given
+// passwords (see userPasswords). Users with invalid rules are reported as
+// attribute errors.
+func (m *ResourceModel) aclUsers(ctx context.Context, passwords map[string]string, d *diag.Diagnostics) []aclUser {
+	if m.Users.IsNull() || m.Users.IsUnknown() {
+		return nil
+	}
+
+	userModels := make(map[string]RedisUserModel)
+	d.Append(m.Users.ElementsAs(ctx, &userModels, false)...)
+
+	users := make([]aclUser, 0, len(userModels))
+
+	for _, name := range slices.Sorted(maps.Keys(userModels)) {
+		userModel := userModels[name]
+		user := aclUser{
+			Name:         name,
+			PasswordHash: hashACLPassword(passwords[name]),
+		}
+
+		if !userModel.Keys.IsUnknown() {
+			d.Append(userModel.Keys.ElementsAs(ctx, &user.Keys, false)...)
+		}
+
+		if !userModel.Channels.IsUnknown() {
+			d.Append(userModel.Channels.ElementsAs(ctx, &user.Channels, false)...)
+		}
+
+		if !userModel.Commands.IsUnknown() {
+			d.Append(userModel.Commands.ElementsAs(ctx, &user.Commands, false)...)
+		}
+
+		if err := user.validate(); err != nil {
+			d.AddAttributeError(path.Root("users").AtMapKey(name), "Invalid Redis user", err.Error())
+		}
+
+		users = append(users, user)
+	}
+
+	return users
 }
 
 func (m *ResourceModel) ToUpdateDescriptionRequest() databaseclientv2.PatchRedisDatabaseRequest {
@@ -53,11 +118,11 @@ func (m *ResourceModel) ToUpdateDescriptionRequest() databaseclientv2.PatchRedis
 	}
 }
 
-func (m *ResourceModel) ToUpdateConfigurationRequest(ctx context.Context, d *diag.Diagnostics) databaseclientv2.PatchRedisDatabaseRequest {
+func (m *ResourceModel) ToUpdateConfigurationRequest(ctx context.Context, users []aclUser, d *diag.Diagnostics) databaseclientv2.PatchRedisDatabaseRequest {
 	return databaseclientv2.PatchRedisDatabaseRequest{
 		RedisDatabaseID: m.ID.ValueString(),
 		Body: databaseclientv2.PatchRedisDatabaseRequestBody{
-			Configuration: m.mapConfiguration(ctx, d),
+			Configuration: m.mapConfiguration(ctx, users, d),
 		},
 	}
 }
@@ -68,7 +133,23 @@ func (m *ResourceModel) ToDeleteRequest() databaseclientv2.DeleteRedisDatabaseRe
 	}
 }
 
-func (m *ResourceModel) FromAPIModel(ctx context.Context, database *databasev2.RedisDatabase) (res diag.Diagnostics) {
+func (m *ResourceModel) Reset() {
+	m.Name = types.StringNull()
+	m.Hostname = types.StringNull()
+	m.Description = types.StringNull()
+	m.Version = types.StringNull()
+	m.ProjectID = types.StringNull()
+	m.Configuration = types.ObjectNull(redisConfigurationAttrs)
+	m.Users = types.MapNull(types.ObjectType{AttrTypes: redisUserAttrs})
+}
+
+// FromAPIModel maps the database into the model; hashes are the password
+// hashes that were last applied by Terraform (see usersFromACL).
+func (m *ResourceModel) FromAPIModel(ctx context.Context, database *databasev2.RedisDatabase, hashes aclPasswordHashes) (res diag.Diagnostics) {
+	if database == nil {
+		m.Reset()
+		return
+	}
 
 	m.Name = types.StringValue(database.Name)
 	m.Hostname = types.StringValue(database.Hostname)
@@ -76,28 +157,89 @@ func (m *ResourceModel) FromAPIModel(ctx context.Context, database *databasev2.R
 	m.Version = types.StringValue(database.Version)
 	m.ProjectID = types.StringValue(database.ProjectId)
 
+	var users []*aclUser
+
 	if database.Configuration != nil {
 		configuration := RedisConfigurationModel{}
-		res.Append(configuration.FromAPIModel(ctx, database.Configuration)...)
+		users = configuration.FromAPIModel(ctx, database.Configuration, &res)
 
 		m.Configuration = configuration.AsObject(ctx, res)
 	} else {
 		m.Configuration = types.ObjectNull(redisConfigurationAttrs)
 	}
 
+	m.usersFromACL(ctx, users, hashes, &res)
+
 	return
 }
 
-func (m *RedisConfigurationModel) FromAPIModel(ctx context.Context, config *databasev2.RedisDatabaseConfiguration) (res diag.Diagnostics) {
+// usersFromACL updates the users map from the ACL users that are actually
+// configured. The passwords are write-only, so they can not be compared
+// directly; instead, if the password hash of a user differs from the hash
+// that was last applied by Terraform, its password_wo_version is cleared, so
+// that the next plan shows a change and re-applies the configured password.
+func (m *ResourceModel) usersFromACL(ctx context.Context, users []*aclUser, hashes aclPasswordHashes, d *diag.Diagnostics) {
+	userType := types.ObjectType{AttrTypes: redisUserAttrs}
+
+	if len(users) == 0 && m.Users.IsNull() {
+		return
+	}
+
+	priorUsers := make(map[string]RedisUserModel)
+	if !m.Users.IsNull() && !m.Users.IsUnknown() {
+		d.Append(m.Users.ElementsAs(ctx, &priorUsers, false)...)
+	}
+
+	userModels := make(map[string]RedisUserModel, len(users))
+
+	for _, user := range users {
+		userModel := RedisUserModel{
+			PasswordWO:        types.StringNull(),
+			PasswordWOVersion: types.Int64Null(),
+			Keys:              valueutil.ConvertStringSliceToList(user.Keys),
+			Channels:          valueutil.ConvertStringSliceToList(user.Channels),
+			Commands:          valueutil.ConvertStringSliceToList(user.Commands),
+		}
+
+		if prior, ok := priorUsers[user.Name]; ok && hashes[user.Name] == user.PasswordHash {
+			userModel.PasswordWOVersion = prior.PasswordWOVersion
+		}
+
+		userModels[user.Name] = userModel
+	}
+
+	usersValue, diags := types.MapValueFrom(ctx, userType, userModels)
+	d.Append(diags...)
+
+	m.Users = usersValue
+}
+
+// FromAPIModel maps the database configuration into the model; server flags
+// that configure ACL users are not mapped into additional_flags, but returned
+// separately.
+func (m *RedisConfigurationModel) FromAPIModel(ctx context.Context, config *databasev2.RedisDatabaseConfiguration, d *diag.Diagnostics) []*aclUser {
 	if maxmem := config.MaxMemory; maxmem != nil {
-		maxMemoryBytes := valueutil.Int64FromByteQuantity(*maxmem, &res)
+		maxMemoryBytes := valueutil.Int64FromByteQuantity(*maxmem, d)
 		m.MaxMemoryMB = types.Int64Value(maxMemoryBytes.ValueInt64() / 1024 / 1024)
 	} else {
 		m.MaxMemoryMB = types.Int64Null()
 	}
 
+	var users []*aclUser
+
+	additionalFlags := make([]string, 0, len(config.AdditionalFlags))
+	for _, flag := range config.AdditionalFlags {
+		if user, ok := parseACLUserFlag(flag); ok {
+			users = append(users, user)
+			continue
+		}
+
+		additionalFlags = append(additionalFlags, flag)
+	}
+
 	m.MaxMemoryPolicy = valueutil.StringPtrOrNull(config.MaxMemoryPolicy)
 	m.Persistent = valueutil.BoolPtrOrNull(config.Persistent)
-	m.AdditionalFlags, res = types.ListValueFrom(ctx, types.StringType, config.AdditionalFlags)
-	return
+	m.AdditionalFlags = valueutil.ConvertStringSliceToList(additionalFlags)
+
+	return users
 }
@@ -16,6 +16,14 @@ var redisConfigurationAttrs = map[string]attr.Type{
 	"persistent":        types.BoolType,
 }
 
+var redisUserAttrs = map[string]attr.Type{
+	"password_wo":         types.StringType,
+	"password_wo_version": types.Int64Type,
+	"keys":                types.ListType{ElemType: types.StringType},
+	"channels":            types.ListType{ElemType: types.StringType},
+	"commands":            types.ListType{ElemType: types.StringType},
+}
+
 func (m *RedisConfigurationModel) AsObject(ctx context.Context, diag diag.Diagnostics) types.Object {
 	val, d := types.ObjectValueFrom(ctx, redisConfigurationAttrs, m)
 	diag.Append(d...)
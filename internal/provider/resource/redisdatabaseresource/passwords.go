@@ -0,0 +1,80 @@
+package redisdatabaseresource
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+)
+
+// aclPasswordHashesPrivateStateKey is the private state key under which the
+// SHA-256 hashes of the last applied user passwords are kept. The passwords
+// themselves are write-only, so these hashes are the only way to tell whether
+// a password was changed outside of Terraform.
+const aclPasswordHashesPrivateStateKey = "acl_password_hashes"
+
+// aclPasswordHashes maps user names to the hashes of their passwords.
+type aclPasswordHashes map[string]string
+
+// passwordHashesOf returns the password hashes of the given users.
+func passwordHashesOf(users []aclUser) aclPasswordHashes {
+	hashes := make(aclPasswordHashes, len(users))
+	for _, user := range users {
+		hashes[user.Name] = user.PasswordHash
+	}
+
+	return hashes
+}
+
+// userPasswords returns the passwords of the given users, keyed by user name.
+// Since the passwords are write-only, users must be read from the
+// configuration; in the plan and state, they are always null.
+func userPasswords(ctx context.Context, users types.Map, d *diag.Diagnostics) map[string]string {
+	passwords := make(map[string]string)
+	if users.IsNull() || users.IsUnknown() {
+		return passwords
+	}
+
+	userModels := make(map[string]RedisUserModel)
+	d.Append(users.ElementsAs(ctx, &userModels, false)...)
+
+	for name, userModel := range userModels {
+		passwords[name] = userModel.PasswordWO.ValueString()
+	}
+
+	return passwords
+}
+
+// readACLPasswordHashes reads the applied password hashes from the private
+// state. A missing key (for example, for imported resources) is treated as
+// "no known passwords".
+func readACLPasswordHashes(ctx context.Context, private common.PrivateStateGetter, d *diag.Diagnostics) aclPasswordHashes {
+	hashes := make(aclPasswordHashes)
+
+	raw, diags := private.GetKey(ctx, aclPasswordHashesPrivateStateKey)
+	d.Append(diags...)
+
+	if len(raw) == 0 {
+		return hashes
+	}
+
+	if err := json.Unmarshal(raw, &hashes); err != nil {
+		d.AddError("error while reading private state", "could not decode password hashes: "+err.Error())
+	}
+
+	return hashes
+}
+
+// writeACLPasswordHashes stores the applied password hashes in the private
+// state.
+func writeACLPasswordHashes(ctx context.Context, private common.PrivateStateSetter, hashes aclPasswordHashes, d *diag.Diagnostics) {
+	raw, err := json.Marshal(hashes)
+	if err != nil {
+		d.AddError("error while writing private state", "could not encode password hashes: "+err.Error())
+		return
+	}
+
+	d.Append(private.SetKey(ctx, aclPasswordHashesPrivateStateKey, raw)...)
+}
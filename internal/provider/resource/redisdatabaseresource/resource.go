@@ -6,6 +6,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -15,12 +17,14 @@ import (
 	"github.com/mittwald/terraform-provider-mittwald/internal/apiutils"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+	"github.com/mittwald/terraform-provider-mittwald/internal/valueutil"
 	"time"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithValidateConfig = &Resource{}
 
 func New() resource.Resource {
 	return &Resource{}
@@ -66,24 +70,75 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				},
 			},
 			"configuration": schema.SingleNestedAttribute{
-				Optional: true,
+				MarkdownDescription: "Configuration of the database; attributes that are not set are chosen by the platform, and changes made outside of Terraform are detected as drift.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
 				Attributes: map[string]schema.Attribute{
 					"additional_flags": schema.ListAttribute{
-						Description: "Additional command-line flags that should be passed to the Redis container",
+						Description: "Additional command-line flags that should be passed to the Redis container; flags for the users configured in `users` are managed separately and not included here",
 						ElementType: types.StringType,
 						Optional:    true,
+						Computed:    true,
 					},
 					"max_memory_mb": schema.Int64Attribute{
 						MarkdownDescription: "The database's maximum memory in MiB",
 						Optional:            true,
+						Computed:            true,
 					},
 					"max_memory_policy": schema.StringAttribute{
 						MarkdownDescription: "The database's key eviction policy. See the Redis documentation on key evictions for more information.",
 						Optional:            true,
+						Computed:            true,
 					},
 					"persistent": schema.BoolAttribute{
 						MarkdownDescription: "Enable persistent storage for this database",
 						Optional:            true,
+						Computed:            true,
+					},
+				},
+			},
+			"users": schema.MapNestedAttribute{
+				MarkdownDescription: "Redis ACL users, keyed by user name. The users are configured as server flags; only a SHA-256 hash of each password is passed to the platform.\n\n" +
+					"Note that the `default` user still allows unauthenticated access with full permissions, unless it is configured here, as well.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"password_wo": schema.StringAttribute{
+							MarkdownDescription: "Password of the user. The password is not stored in the state; only its SHA-256 hash is kept " +
+								"(in the private state) to detect changes made outside of Terraform.",
+							Required:  true,
+							Sensitive: true,
+							WriteOnly: true,
+						},
+						"password_wo_version": schema.Int64Attribute{
+							MarkdownDescription: "Version of the password of the user; increment this to apply a changed `password_wo`. " +
+								"If the password was changed outside of Terraform, the next plan shows a change to this attribute, and the configured password is applied again.",
+							Required: true,
+						},
+						"keys": schema.ListAttribute{
+							MarkdownDescription: "Key patterns that the user may access, e.g. `app:*`; defaults to all keys",
+							ElementType:         types.StringType,
+							Optional:            true,
+							Computed:            true,
+							Default:             listdefault.StaticValue(valueutil.ConvertStringSliceToList([]string{"*"})),
+						},
+						"channels": schema.ListAttribute{
+							MarkdownDescription: "Pub/Sub channel patterns that the user may access, e.g. `events:*`; defaults to all channels. Set this to an empty list to deny access to all channels.",
+							ElementType:         types.StringType,
+							Optional:            true,
+							Computed:            true,
+							Default:             listdefault.StaticValue(valueutil.ConvertStringSliceToList([]string{"*"})),
+						},
+						"commands": schema.ListAttribute{
+							MarkdownDescription: "Command rules for the user, e.g. `+@read` or `-flushall`; defaults to all commands",
+							ElementType:         types.StringType,
+							Optional:            true,
+							Computed:            true,
+							Default:             listdefault.StaticValue(valueutil.ConvertStringSliceToList([]string{"+@all"})),
+						},
 					},
 				},
 			},
@@ -91,17 +146,35 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 	}
 }
 
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.aclUsers(ctx, userPasswords(ctx, data.Users, &resp.Diagnostics), &resp.Diagnostics)
+}
+
 func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	r.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ResourceModel
+	var configUsers types.Map
 
 	client := r.client.Database()
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("users"), &configUsers)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	users := data.aclUsers(ctx, userPasswords(ctx, configUsers, &resp.Diagnostics), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -110,7 +183,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		Try[*databaseclientv2.CreateRedisDatabaseResponse](&resp.Diagnostics, "error while creating database").
 		DoValResp(client.CreateRedisDatabase(
 			ctx,
-			data.ToCreateRequest(ctx, &resp.Diagnostics),
+			data.ToCreateRequest(ctx, users, &resp.Diagnostics),
 		))
 
 	if resp.Diagnostics.HasError() {
@@ -119,7 +192,10 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	data.ID = types.StringValue(databaseResponse.Id)
 
-	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	hashes := passwordHashesOf(users)
+	writeACLPasswordHashes(ctx, resp.Private, hashes, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(r.read(ctx, &data, hashes)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -133,14 +209,26 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	hashes := readACLPasswordHashes(ctx, req.Private, &resp.Diagnostics)
+
 	readCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	resp.Diagnostics.Append(r.read(readCtx, &data)...)
+	resp.Diagnostics.Append(r.read(readCtx, &data, hashes)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Name.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *Resource) read(ctx context.Context, data *ResourceModel) (res diag.Diagnostics) {
+func (r *Resource) read(ctx context.Context, data *ResourceModel, hashes aclPasswordHashes) (res diag.Diagnostics) {
 	client := r.client.Database()
 
 	database := providerutil.
@@ -152,7 +240,7 @@ func (r *Resource) read(ctx context.Context, data *ResourceModel) (res diag.Diag
 		return
 	}
 
-	res.Append(data.FromAPIModel(ctx, database)...)
+	res.Append(data.FromAPIModel(ctx, database, hashes)...)
 
 	return
 }
@@ -174,10 +262,23 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		}
 	}
 
-	if !dataPlan.Configuration.Equal(dataState.Configuration) {
-		updateReq := dataPlan.ToUpdateConfigurationRequest(ctx, &resp.Diagnostics)
+	hashes := readACLPasswordHashes(ctx, req.Private, &resp.Diagnostics)
+
+	if !dataPlan.Configuration.Equal(dataState.Configuration) || !dataPlan.Users.Equal(dataState.Users) {
+		var configUsers types.Map
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("users"), &configUsers)...)
+
+		users := dataPlan.aclUsers(ctx, userPasswords(ctx, configUsers, &resp.Diagnostics), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		updateReq := dataPlan.ToUpdateConfigurationRequest(ctx, users, &resp.Diagnostics)
 		if _, err := r.client.Database().PatchRedisDatabase(ctx, updateReq); err != nil {
 			resp.Diagnostics.AddError("Error while updating database configuration", err.Error())
+		} else {
+			hashes = passwordHashesOf(users)
+			writeACLPasswordHashes(ctx, resp.Private, hashes, &resp.Diagnostics)
 		}
 	}
 
@@ -185,6 +286,10 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	// Read back the configuration, since computed values might have been
+	// chosen by the platform.
+	resp.Diagnostics.Append(r.read(ctx, &dataPlan, hashes)...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataPlan)...)
 }
@@ -0,0 +1,145 @@
+package remotedirectoryresource
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// localFile is a file that should be synced to the remote directory. It is
+// either backed by a file on the local filesystem, or by in-memory contents
+// (when specified via the `files` attribute).
+type localFile struct {
+	localPath string
+	contents  []byte
+	hash      string
+}
+
+func (f localFile) open() (io.ReadCloser, error) {
+	if f.localPath != "" {
+		return os.Open(f.localPath)
+	}
+
+	return io.NopCloser(bytes.NewReader(f.contents)), nil
+}
+
+// collectLocalFiles builds the set of files to sync, keyed by their path
+// relative to the target directory. Files from the `files` map take precedence
+// over files with the same path in the source directory.
+func collectLocalFiles(source string, files map[string]string) (map[string]localFile, error) {
+	result := make(map[string]localFile)
+
+	if source != "" {
+		err := filepath.WalkDir(source, func(p string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !entry.Type().IsRegular() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(source, p)
+			if err != nil {
+				return err
+			}
+
+			hash, err := hashLocalFile(p)
+			if err != nil {
+				return err
+			}
+
+			result[filepath.ToSlash(rel)] = localFile{localPath: p, hash: hash}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reading source directory %s: %w", source, err)
+		}
+	}
+
+	for name, contents := range files {
+		rel, err := cleanRelativePath(name)
+		if err != nil {
+			return nil, err
+		}
+
+		result[rel] = localFile{contents: []byte(contents), hash: hashBytes([]byte(contents))}
+	}
+
+	return result, nil
+}
+
+// cleanRelativePath normalizes a path from the `files` attribute, and makes
+// sure that it does not point outside of the target directory.
+func cleanRelativePath(name string) (string, error) {
+	cleaned := pathpkg.Clean(filepath.ToSlash(name))
+
+	if pathpkg.IsAbs(cleaned) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("file path %q must be relative to the target directory", name)
+	}
+
+	return cleaned, nil
+}
+
+func hashBytes(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hashReader(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashLocalFile(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	return hashReader(f)
+}
+
+func fileHashes(files map[string]localFile) map[string]string {
+	hashes := make(map[string]string, len(files))
+	for name, f := range files {
+		hashes[name] = f.hash
+	}
+
+	return hashes
+}
+
+// planSync determines which files need to be uploaded (because they are
+// missing remotely or their hash differs) and which files need to be removed
+// (because they exist remotely, but not locally). Both lists are sorted.
+func planSync(desired, actual map[string]string) (upload []string, remove []string) {
+	for name, hash := range desired {
+		if actualHash, ok := actual[name]; !ok || actualHash != hash {
+			upload = append(upload, name)
+		}
+	}
+
+	for name := range actual {
+		if _, ok := desired[name]; !ok {
+			remove = append(remove, name)
+		}
+	}
+
+	sort.Strings(upload)
+	sort.Strings(remove)
+
+	return upload, remove
+}
@@ -0,0 +1,62 @@
+package remotedirectoryresource
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCollectLocalFiles(t *testing.T) {
+	g := NewWithT(t)
+
+	source := t.TempDir()
+	g.Expect(os.MkdirAll(filepath.Join(source, "css"), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(source, "index.html"), []byte("<h1>hi</h1>"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(source, "css", "style.css"), []byte("body {}"), 0o644)).To(Succeed())
+
+	files, err := collectLocalFiles(source, map[string]string{
+		"./css/style.css": "overridden",
+		"config.json":     "{}",
+	})
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fileHashes(files)).To(Equal(map[string]string{
+		"index.html":    hashBytes([]byte("<h1>hi</h1>")),
+		"css/style.css": hashBytes([]byte("overridden")),
+		"config.json":   hashBytes([]byte("{}")),
+	}))
+}
+
+func TestCollectLocalFilesRejectsPathsOutsideTarget(t *testing.T) {
+	for _, name := range []string{"../etc/passwd", "/etc/passwd", "."} {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := collectLocalFiles("", map[string]string{name: "x"})
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
+
+func TestPlanSync(t *testing.T) {
+	g := NewWithT(t)
+
+	desired := map[string]string{
+		"unchanged.txt": "a",
+		"changed.txt":   "b",
+		"new.txt":       "c",
+	}
+
+	actual := map[string]string{
+		"unchanged.txt": "a",
+		"changed.txt":   "x",
+		"extra.txt":     "y",
+	}
+
+	upload, remove := planSync(desired, actual)
+
+	g.Expect(upload).To(Equal([]string{"changed.txt", "new.txt"}))
+	g.Expect(remove).To(Equal([]string{"extra.txt"}))
+}
@@ -0,0 +1,113 @@
+package remotedirectoryresource
+
+import (
+	"fmt"
+	"os"
+	pathpkg "path"
+	"strings"
+
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
+)
+
+// remoteDirectory wraps an SFTP session for operations on a single target
+// directory; all file names are relative to root.
+type remoteDirectory struct {
+	session *sshutil.SFTPSession
+	root    string
+}
+
+func (d *remoteDirectory) path(name string) string {
+	return pathpkg.Join(d.root, name)
+}
+
+// hashes returns the SHA-256 hashes of the given files; files that do not
+// exist remotely are omitted. If all is set, all other regular files in the
+// directory tree are hashed, too.
+func (d *remoteDirectory) hashes(names []string, all bool) (map[string]string, error) {
+	if all {
+		found, err := d.list()
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, found...)
+	}
+
+	result := make(map[string]string, len(names))
+
+	for _, name := range names {
+		if _, ok := result[name]; ok {
+			continue
+		}
+
+		hash, exists, err := d.hash(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if exists {
+			result[name] = hash
+		}
+	}
+
+	return result, nil
+}
+
+func (d *remoteDirectory) hash(name string) (string, bool, error) {
+	f, err := d.session.Open(d.path(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to open %s: %w", d.path(name), err)
+	}
+	defer func() { _ = f.Close() }()
+
+	hash, err := hashReader(f)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s: %w", d.path(name), err)
+	}
+
+	return hash, true, nil
+}
+
+// list returns the names of all regular files in the directory tree.
+func (d *remoteDirectory) list() ([]string, error) {
+	var names []string
+
+	walker := d.session.Walk(d.root)
+	for walker.Step() {
+		if err := walker.Err(); err != nil {
+			if os.IsNotExist(err) && walker.Path() == d.root {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to list %s: %w", walker.Path(), err)
+		}
+
+		if !walker.Stat().Mode().IsRegular() {
+			continue
+		}
+
+		names = append(names, strings.TrimPrefix(strings.TrimPrefix(walker.Path(), d.root), "/"))
+	}
+
+	return names, nil
+}
+
+func (d *remoteDirectory) upload(name string, file localFile) error {
+	src, err := file.open()
+	if err != nil {
+		return fmt.Errorf("failed to open local file for %s: %w", name, err)
+	}
+	defer func() { _ = src.Close() }()
+
+	return sshutil.WriteFileAtomic(d.session.Client, d.path(name), src, sshutil.FileAttributes{})
+}
+
+func (d *remoteDirectory) remove(name string) error {
+	if err := d.session.Remove(d.path(name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove file %s: %w", d.path(name), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,409 @@
+package remotedirectoryresource
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
+	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
+)
+
+var _ resource.Resource = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
+
+func New() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client  mittwaldv2.Client
+	sshPool *sshutil.Pool
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_remote_directory"
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource allows you to sync a local directory tree (or a set of files) to a directory on a remote server via SFTP.\n\n" +
+			"You can specify either a container_id or an app_id to determine which server to connect to. " +
+			"The SSH hostname is dynamically determined from the project that the app or container belongs to, " +
+			"and the SSH username defaults to the currently authenticated user if not specified.\n\n" +
+			"Drift is detected by comparing the SHA-256 hashes of the remote files with those of the local files; " +
+			"all files are transferred using a single SSH connection.\n\n" +
+			"If the SSH host key cannot be verified (see the `ssh_known_hosts_file` provider attribute), it is trusted on first use and pinned in the state of this resource; connections are refused if the host key changes later on.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the remote directory resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"container_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of the container to connect to. Must be a full UUID (not a short ID like c-XXXXXX). Either container_id+stack_id or app_id must be specified.",
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"stack_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of the stack that the container belongs to. Required when container_id is specified.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"app_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of the app to connect to. Must be a full UUID (not a short ID like a-XXXXXX). Either container_id+stack_id or app_id must be specified.",
+				Validators: []validator.String{
+					&common.UUIDValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ssh_user": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The SSH username to use for the connection. If not specified, it will default to the currently authenticated user.",
+			},
+			"ssh_private_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The SSH private key to use for the connection. If not specified, " + sshutil.DefaultAuthDescription + "; use the file function to specify a file path instead.",
+			},
+			"path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The path of the target directory on the remote server.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The path of a local directory whose contents should be synced to the remote directory. Either source or files (or both) must be specified.",
+			},
+			"files": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "A map of file paths (relative to the remote directory) to file contents. Entries in this map take precedence over files with the same path in source. Either source or files (or both) must be specified.",
+			},
+			"delete_unmanaged": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether to delete remote files in the target directory that are not present locally. Files that were previously synced by this resource and were removed locally are always deleted.",
+			},
+			"file_hashes": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "A map of file paths (relative to the remote directory) to the SHA-256 hashes of their contents.",
+			},
+		},
+	}
+}
+
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+	r.sshPool = providerutil.SSHPoolFromProviderData(req.ProviderData)
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Source.IsUnknown() || data.Files.IsUnknown() {
+		data.FileHashes = types.MapUnknown(types.StringType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+		return
+	}
+
+	files := data.localFiles(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hashes, d := types.MapValueFrom(ctx, types.StringType, fileHashes(files))
+	resp.Diagnostics.Append(d...)
+
+	data.FileHashes = hashes
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.validateTarget(&resp.Diagnostics) {
+		return
+	}
+
+	// The host key is trusted on first use and pinned in the private state
+	pins := make(sshutil.HostKeyPins)
+
+	r.sync(ctx, &data, nil, pins, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ContainerID.IsNull() {
+		data.ID = types.StringValue(fmt.Sprintf("container-%s-%s-%s", data.StackID.ValueString(), data.ContainerID.ValueString(), data.Path.ValueString()))
+	} else {
+		data.ID = types.StringValue(fmt.Sprintf("app-%s-%s", data.AppID.ValueString(), data.Path.ValueString()))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	common.WriteSSHHostKeyPins(ctx, resp.Private, pins, &resp.Diagnostics)
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	pins := common.ReadSSHHostKeyPins(ctx, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	session := r.connect(ctx, &data, pins, &resp.Diagnostics)
+	if session == nil {
+		return
+	}
+	defer r.close(ctx, session)
+
+	dir := remoteDirectory{session: session, root: data.Path.ValueString()}
+
+	hashes, err := dir.hashes(data.managedFileNames(ctx, &resp.Diagnostics), data.DeleteUnmanaged.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Remote Directory", fmt.Sprintf("Could not read directory %s: %s", data.Path.ValueString(), err))
+		return
+	}
+
+	hashesValue, d := types.MapValueFrom(ctx, types.StringType, hashes)
+	resp.Diagnostics.Append(d...)
+
+	data.FileHashes = hashesValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	common.WriteSSHHostKeyPins(ctx, resp.Private, pins, &resp.Diagnostics)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	pins := common.ReadSSHHostKeyPins(ctx, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.sync(ctx, &data, state.managedFileNames(ctx, &resp.Diagnostics), pins, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	common.WriteSSHHostKeyPins(ctx, resp.Private, pins, &resp.Diagnostics)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	pins := common.ReadSSHHostKeyPins(ctx, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	session := r.connect(ctx, &data, pins, &resp.Diagnostics)
+	if session == nil {
+		return
+	}
+	defer r.close(ctx, session)
+
+	dir := remoteDirectory{session: session, root: data.Path.ValueString()}
+
+	for _, name := range data.managedFileNames(ctx, &resp.Diagnostics) {
+		if err := dir.remove(name); err != nil {
+			resp.Diagnostics.AddError("Error Deleting Remote Directory", err.Error())
+			return
+		}
+	}
+}
+
+// sync uploads all changed files and removes files that are not present
+// locally any more. previouslyManaged contains the names of files that were
+// synced by a previous apply; these are removed if they are not present
+// locally any more, even if delete_unmanaged is not set.
+func (r *Resource) sync(ctx context.Context, data *ResourceModel, previouslyManaged []string, pins sshutil.HostKeyPins, d *diag.Diagnostics) {
+	files := data.localFiles(ctx, d)
+	if d.HasError() {
+		return
+	}
+
+	desired := fileHashes(files)
+
+	if !data.FileHashes.IsUnknown() {
+		planned := make(map[string]string)
+		d.Append(data.FileHashes.ElementsAs(ctx, &planned, false)...)
+
+		if !maps.Equal(planned, desired) {
+			d.AddError("Local Files Changed", "The local files changed after the plan was created; please re-run the plan.")
+			return
+		}
+	}
+
+	session := r.connect(ctx, data, pins, d)
+	if session == nil {
+		return
+	}
+	defer r.close(ctx, session)
+
+	dir := remoteDirectory{session: session, root: data.Path.ValueString()}
+
+	names := append(slices.Collect(maps.Keys(desired)), previouslyManaged...)
+
+	actual, err := dir.hashes(names, data.DeleteUnmanaged.ValueBool())
+	if err != nil {
+		d.AddError("Error Syncing Remote Directory", fmt.Sprintf("Could not read directory %s: %s", data.Path.ValueString(), err))
+		return
+	}
+
+	upload, remove := planSync(desired, actual)
+
+	tflog.Debug(ctx, "syncing remote directory", map[string]any{
+		"path":   data.Path.ValueString(),
+		"upload": len(upload),
+		"remove": len(remove),
+	})
+
+	for _, name := range upload {
+		if err := dir.upload(name, files[name]); err != nil {
+			d.AddError("Error Syncing Remote Directory", err.Error())
+			return
+		}
+	}
+
+	for _, name := range remove {
+		if err := dir.remove(name); err != nil {
+			d.AddError("Error Syncing Remote Directory", err.Error())
+			return
+		}
+	}
+
+	hashes, diags := types.MapValueFrom(ctx, types.StringType, desired)
+	d.Append(diags...)
+
+	data.FileHashes = hashes
+}
+
+// connect starts an SFTP session on a pooled SSH connection to the target of
+// the resource, verifying the host key against pins.
+func (r *Resource) connect(ctx context.Context, data *ResourceModel, pins sshutil.HostKeyPins, d *diag.Diagnostics) *sshutil.SFTPSession {
+	details, err := apiext.ResolveSSHConnectionDetails(ctx, r.client, apiext.SSHTarget{
+		AppID:       data.AppID.ValueString(),
+		ContainerID: data.ContainerID.ValueString(),
+		StackID:     data.StackID.ValueString(),
+		User:        data.SSHUser.ValueString(),
+	})
+	if err != nil {
+		d.AddError("SSH Connection Error", "Could not determine SSH connection details: "+err.Error())
+		return nil
+	}
+
+	session, err := r.sshPool.AcquireSFTP(ctx, details.Host, details.User, data.SSHPrivateKey.ValueString(), pins, d)
+	if err != nil {
+		d.AddError("SSH Connection Error", fmt.Sprintf("Could not connect to %s: %s", details.Host, err))
+		return nil
+	}
+
+	return session
+}
+
+func (r *Resource) close(ctx context.Context, session *sshutil.SFTPSession) {
+	if err := session.Close(); err != nil {
+		tflog.Error(ctx, "Failed to close SFTP session", map[string]any{"error": err})
+	}
+}
+
+func (m *ResourceModel) validateTarget(d *diag.Diagnostics) bool {
+	if m.ContainerID.IsNull() == m.AppID.IsNull() {
+		d.AddAttributeError(path.Root("container_id"), "Invalid Resource Reference", "Exactly one of container_id or app_id must be specified.")
+		return false
+	}
+
+	if !m.ContainerID.IsNull() && m.StackID.IsNull() {
+		d.AddAttributeError(path.Root("stack_id"), "Missing Stack ID", "stack_id must be specified when container_id is specified.")
+		return false
+	}
+
+	return true
+}
+
+func (m *ResourceModel) localFiles(ctx context.Context, d *diag.Diagnostics) map[string]localFile {
+	if m.Source.IsNull() && m.Files.IsNull() {
+		d.AddAttributeError(path.Root("source"), "Missing Directory Contents", "Either source or files must be specified.")
+		return nil
+	}
+
+	files := make(map[string]string)
+	if !m.Files.IsNull() {
+		d.Append(m.Files.ElementsAs(ctx, &files, false)...)
+	}
+
+	result, err := collectLocalFiles(m.Source.ValueString(), files)
+	if err != nil {
+		d.AddAttributeError(path.Root("source"), "Error Reading Local Files", err.Error())
+		return nil
+	}
+
+	return result
+}
+
+// managedFileNames returns the names of all files that are tracked in the
+// state.
+func (m *ResourceModel) managedFileNames(ctx context.Context, d *diag.Diagnostics) []string {
+	hashes := make(map[string]string)
+	if !m.FileHashes.IsNull() && !m.FileHashes.IsUnknown() {
+		d.Append(m.FileHashes.ElementsAs(ctx, &hashes, false)...)
+	}
+
+	names := slices.Collect(maps.Keys(hashes))
+	slices.Sort(names)
+
+	return names
+}
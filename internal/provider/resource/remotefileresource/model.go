@@ -1,6 +1,11 @@
 package remotefileresource
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
+)
 
 // ResourceModel describes the resource data model.
 type ResourceModel struct {
@@ -13,4 +18,56 @@ type ResourceModel struct {
 	Path            types.String `tfsdk:"path"`
 	Contents        types.String `tfsdk:"contents"`
 	ContentsFromURL types.String `tfsdk:"contents_from_url"`
+	ContentBase64   types.String `tfsdk:"content_base64"`
+	Source          types.String `tfsdk:"source"`
+	SHA256          types.String `tfsdk:"sha256"`
+	Mode            types.String `tfsdk:"mode"`
+	Owner           types.Int64  `tfsdk:"owner"`
+	Group           types.Int64  `tfsdk:"group"`
+}
+
+// fileAttributes returns the file attributes that should be applied when
+// writing the file. The mode has already been validated by the schema.
+func (m *ResourceModel) fileAttributes() sshutil.FileAttributes {
+	attrs := sshutil.FileAttributes{}
+
+	if !m.Mode.IsNull() {
+		if mode, err := sshutil.ParseFileMode(m.Mode.ValueString()); err == nil {
+			attrs.Mode = &mode
+		}
+	}
+
+	if !m.Owner.IsNull() {
+		uid := int(m.Owner.ValueInt64())
+		attrs.UID = &uid
+	}
+
+	if !m.Group.IsNull() {
+		gid := int(m.Group.ValueInt64())
+		attrs.GID = &gid
+	}
+
+	return attrs
+}
+
+// readFileAttributes updates the mode and ownership attributes from the remote
+// file; attributes that are not managed (i.e. not set) are left alone.
+func (m *ResourceModel) readFileAttributes(info os.FileInfo) {
+	// Retain the configured notation (like "640" instead of "0640") unless
+	// the mode actually differs.
+	if !m.Mode.IsNull() {
+		if mode, err := sshutil.ParseFileMode(m.Mode.ValueString()); err != nil || sshutil.FormatFileMode(mode) != sshutil.FormatFileMode(info.Mode()) {
+			m.Mode = types.StringValue(sshutil.FormatFileMode(info.Mode()))
+		}
+	}
+
+	uid, gid := sshutil.FileOwner(info)
+
+	if !m.Owner.IsNull() && uid >= 0 {
+		m.Owner = types.Int64Value(int64(uid))
+	}
+
+	if !m.Group.IsNull() && gid >= 0 {
+		m.Group = types.Int64Value(int64(gid))
+	}
 }
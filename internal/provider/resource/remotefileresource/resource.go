@@ -1,7 +1,11 @@
 package remotefileresource
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -13,33 +17,29 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	mittwaldv2 "github.com/mittwald/api-client-go/mittwaldv2/generated/clients"
-	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/appclientv2"
-	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/containerclientv2"
-	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/projectclientv2"
-	"github.com/mittwald/api-client-go/mittwaldv2/generated/clients/userclientv2"
+	"github.com/mittwald/terraform-provider-mittwald/internal/apiext"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/providerutil"
 	"github.com/mittwald/terraform-provider-mittwald/internal/provider/resource/common"
 	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
 	"github.com/pkg/sftp"
-	"golang.org/x/crypto/ssh"
 	"io"
 	"net/http"
 	"os"
-	"path/filepath"
 	"time"
 )
 
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
+var _ resource.ResourceWithConfigValidators = &Resource{}
 
 func New() resource.Resource {
 	return &Resource{}
 }
 
-const DefaultSSHKeyPath = ".ssh/id_rsa"
-
 type Resource struct {
-	client mittwaldv2.Client
+	client  mittwaldv2.Client
+	sshPool *sshutil.Pool
 }
 
 func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,7 +51,10 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 		MarkdownDescription: "This resource allows you to create and manage files on a remote server via SSH.\n\n" +
 			"You can specify either a container_id or an app_id to determine which server to connect to. " +
 			"The SSH hostname is dynamically determined from the project that the app or container belongs to, " +
-			"and the SSH username defaults to the currently authenticated user if not specified.",
+			"and the SSH username defaults to the currently authenticated user if not specified.\n\n" +
+			"If the SSH host key cannot be verified (see the `ssh_known_hosts_file` provider attribute), it is trusted on first use and pinned in the state of this resource; connections are refused if the host key changes later on.\n\n" +
+			"Exactly one of `contents`, `content_base64`, `source` or `contents_from_url` must be specified. " +
+			"Previous versions of this provider silently used the first of these attributes (in this order) when several were specified; such configurations are now rejected during planning.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -85,7 +88,7 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 			},
 			"ssh_private_key": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "The SSH private key to use for the connection. If not specified, it will default to the contents ~/" + DefaultSSHKeyPath + "; use the file function to specify a file path instead.",
+				MarkdownDescription: "The SSH private key to use for the connection. If not specified, " + sshutil.DefaultAuthDescription + "; use the file function to specify a file path instead.",
 			},
 			"path": schema.StringAttribute{
 				Required:            true,
@@ -97,14 +100,80 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 			},
 			"contents_from_url": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "The URL to fetch the contents of the file from. If specified and contents is not set, the file will be fetched from this URL.",
+				MarkdownDescription: "The URL to fetch the contents of the file from. Note that the URL is also fetched during planning, to detect changes of its contents.",
+			},
+			"content_base64": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The base64-encoded contents of the file; use this for binary files, for example in combination with the filebase64 function.",
+			},
+			"source": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The path of a local file to upload. Unlike using the file function with contents, this also works for binary files, and the file contents are not stored in the state.",
+			},
+			"sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SHA-256 hash of the file contents, as hex string. This is used for detecting changes of the remote file.",
+			},
+			"mode": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The file mode as an octal string (like `0640`). If not specified, the mode of an existing file is retained, and new files are created with the server's default mode.",
+				Validators: []validator.String{
+					&fileModeValidator{},
+				},
+			},
+			"owner": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The numeric ID of the user that should own the file. If not specified, the owner is not changed.",
+			},
+			"group": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The numeric ID of the group that should own the file. If not specified, the group is not changed.",
 			},
 		},
 	}
 }
 
+func (r *Resource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		&exactlyOneOfValidator{
+			attributes: []string{"contents", "content_base64", "source", "contents_from_url"},
+		},
+	}
+}
+
 func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	r.client = providerutil.ClientFromProviderData(req.ProviderData, &resp.Diagnostics)
+	r.sshPool = providerutil.SSHPoolFromProviderData(req.ProviderData)
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Contents.IsUnknown() || data.ContentBase64.IsUnknown() || data.Source.IsUnknown() || data.ContentsFromURL.IsUnknown() {
+		data.SHA256 = types.StringUnknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+		return
+	}
+
+	contents, err := r.fetchContents(ctx, &data, &resp.Diagnostics)
+	if err != nil {
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.AddError("Error Reading File Contents", err.Error())
+		}
+		return
+	}
+
+	data.SHA256 = types.StringValue(hashContents(contents))
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -144,8 +213,10 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
-	// Create the file on the remote server
-	if err := r.createOrUpdateFile(ctx, &data, &resp.Diagnostics); err != nil {
+	// Create the file on the remote server; the host key is trusted on first
+	// use and pinned in the private state
+	pins := make(sshutil.HostKeyPins)
+	if err := r.createOrUpdateFile(ctx, &data, pins, &resp.Diagnostics); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Remote File",
 			fmt.Sprintf("Could not create file at %s: %s", data.Path.ValueString(), err),
@@ -164,6 +235,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	common.WriteSSHHostKeyPins(ctx, resp.Private, pins, &resp.Diagnostics)
 }
 
 func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -174,7 +246,12 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
-	exists, contents, err := r.readFile(ctx, data, &resp.Diagnostics)
+	pins := common.ReadSSHHostKeyPins(ctx, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	file, err := r.readFile(ctx, data, pins, &resp.Diagnostics)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Remote File",
@@ -183,19 +260,24 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
-	if !exists {
+	if file == nil {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	// Only read contents if they are not read from a URL. We don't know what
-	// kind of content the URL will return, so we don't want to end up with
-	// binary data in the contents field.
-	if data.ContentsFromURL.IsNull() {
-		data.Contents = types.StringValue(contents)
+	// Contents are only read back when they were specified as a string; for
+	// all other sources, drift is detected by the hash alone. This way, we
+	// never end up with binary data in the contents field.
+	if !data.Contents.IsNull() {
+		data.Contents = types.StringValue(file.contents)
 	}
 
+	data.SHA256 = types.StringValue(file.sha256)
+
+	data.readFileAttributes(file.info)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	common.WriteSSHHostKeyPins(ctx, resp.Private, pins, &resp.Diagnostics)
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -204,11 +286,13 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
+	pins := common.ReadSSHHostKeyPins(ctx, req.Private, &resp.Diagnostics)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.createOrUpdateFile(ctx, &data, &resp.Diagnostics); err != nil {
+	if err := r.createOrUpdateFile(ctx, &data, pins, &resp.Diagnostics); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Remote File",
 			fmt.Sprintf("Could not update file at %s: %s", data.Path.ValueString(), err),
@@ -217,6 +301,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	common.WriteSSHHostKeyPins(ctx, resp.Private, pins, &resp.Diagnostics)
 }
 
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -227,7 +312,12 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
-	if err := r.deleteFile(ctx, data, &resp.Diagnostics); err != nil {
+	pins := common.ReadSSHHostKeyPins(ctx, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.deleteFile(ctx, data, pins, &resp.Diagnostics); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Remote File",
 			fmt.Sprintf("Could not delete file at %s: %s", data.Path.ValueString(), err),
@@ -242,154 +332,33 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 
 // Helper functions for SSH operations
 
-func (r *Resource) getSSHConnectionDetails(ctx context.Context, data *ResourceModel) (string, string, error) {
-	projectID, shortID, err := r.determineProjectAndTargetID(ctx, data)
-	if err != nil {
-		return "", "", err
-	}
-
-	sshHost, err := r.determineSSHHostname(ctx, projectID)
-	if err != nil {
-		return "", "", err
-	}
-
-	username, err := r.determineSSHUsername(ctx, data)
-	if err != nil {
-		return "", "", fmt.Errorf("error determining SSH username: %w", err)
-	}
-
-	// Always format as "<username>@<shortid>"
-	sshUser := fmt.Sprintf("%s@%s", username, shortID)
-
-	tflog.Debug(ctx, "Using SSH connection details", map[string]interface{}{
-		"host": sshHost,
-		"user": sshUser,
-	})
-
-	return sshHost, sshUser, nil
-}
-
-func (r *Resource) determineSSHHostname(ctx context.Context, projectID string) (string, error) {
-	// Get project details to determine SSH host
-	projectRequest := projectclientv2.GetProjectRequest{ProjectID: projectID}
-	project, _, err := r.client.Project().GetProject(ctx, projectRequest)
-	if err != nil {
-		return "", fmt.Errorf("error getting project details: %w", err)
-	}
-
-	// Determine SSH host from project details
-	if project.ClusterID != nil && project.ClusterDomain != nil {
-		return fmt.Sprintf("ssh.%s.%s", *project.ClusterID, *project.ClusterDomain), nil
+func (r *Resource) sshTarget(data *ResourceModel) apiext.SSHTarget {
+	return apiext.SSHTarget{
+		AppID:       data.AppID.ValueString(),
+		ContainerID: data.ContainerID.ValueString(),
+		StackID:     data.StackID.ValueString(),
+		User:        data.SSHUser.ValueString(),
 	}
-
-	return "", fmt.Errorf("project %s does not have cluster information", projectID)
 }
 
-func (r *Resource) determineSSHUsername(ctx context.Context, data *ResourceModel) (string, error) {
-	if !data.SSHUser.IsNull() {
-		return data.SSHUser.ValueString(), nil
-	}
-
-	// Use the currently logged-in user's email address
-	// Get the user from the client
-	userClient := r.client.User()
-	user, _, err := userClient.GetUser(ctx, userclientv2.GetUserRequest{UserID: "self"})
-	if err != nil {
-		return "", fmt.Errorf("error getting user details: %w", err)
-	}
-
-	if user.Email != nil {
-		return *user.Email, nil
-	}
-
-	return "", fmt.Errorf("user email is not available, cannot determine SSH username")
-}
-
-func (r *Resource) determineProjectAndTargetID(ctx context.Context, data *ResourceModel) (string, string, error) {
-	// Get project ID from either container ID or app ID
-	if !data.ContainerID.IsNull() && !data.StackID.IsNull() {
-		containerClient := r.client.Container()
-		container, _, err := containerClient.GetService(ctx, containerclientv2.GetServiceRequest{
-			ServiceID: data.ContainerID.ValueString(),
-			StackID:   data.StackID.ValueString(),
-		})
-
-		if err != nil {
-			return "", "", fmt.Errorf("error getting container details: %w", err)
-		}
-
-		return container.ProjectId, container.ShortId, nil
-	}
-
-	if !data.AppID.IsNull() {
-		// Get project ID from app ID
-		appClient := r.client.App()
-		appInstallation, _, err := appClient.GetAppinstallation(ctx, appclientv2.GetAppinstallationRequest{
-			AppInstallationID: data.AppID.ValueString(),
-		})
-		if err != nil {
-			return "", "", fmt.Errorf("error getting app installation details: %w", err)
-		}
-
-		return appInstallation.ProjectId, appInstallation.ShortId, nil
-	}
-
-	return "", "", fmt.Errorf("either container_id+stack_id or app_id must be specified")
-}
-
-func (r *Resource) createSSHClient(ctx context.Context, data *ResourceModel, d *diag.Diagnostics) (*ssh.Client, error) {
-	// Get SSH connection details
-	host, user, err := r.getSSHConnectionDetails(ctx, data)
+// openSFTP starts an SFTP session on a pooled SSH connection to the target of
+// the resource, verifying the host key against pins; the session must be
+// closed after use.
+func (r *Resource) openSFTP(ctx context.Context, data *ResourceModel, pins sshutil.HostKeyPins, d *diag.Diagnostics) (*sshutil.SFTPSession, error) {
+	details, err := apiext.ResolveSSHConnectionDetails(ctx, r.client, r.sshTarget(data))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get SSH connection details: %w", err)
 	}
 
-	// Get private key
-	privateKey := ""
-	if !data.SSHPrivateKey.IsNull() {
-		privateKey = data.SSHPrivateKey.ValueString()
-	}
-
-	// If privateKey is empty, use the default ~/.ssh/id_rsa
-	if privateKey == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil, fmt.Errorf("could not get user home directory: %w", err)
-		}
-
-		defaultKeyPath := filepath.Join(homeDir, DefaultSSHKeyPath)
-
-		keyBytes, err := os.ReadFile(defaultKeyPath)
-		if err != nil {
-			return nil, fmt.Errorf("unable to read default private key: %w", err)
-		}
-		privateKey = string(keyBytes)
-	}
-
-	tflog.Debug(ctx, "Using SSH private key")
-
-	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
-	if err != nil {
-		return nil, fmt.Errorf("unable to parse private key: %w", err)
-	}
-
-	config := &ssh.ClientConfig{
-		User: user,
-		Auth: []ssh.AuthMethod{
-			ssh.PublicKeys(signer),
-		},
-		HostKeyCallback: sshutil.VerifyKnownClusters(d),
-	}
-
-	client, err := ssh.Dial("tcp", fmt.Sprintf("%s:22", host), config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to dial: %w", err)
-	}
+	tflog.Debug(ctx, "Using SSH connection details", map[string]interface{}{
+		"host": details.Host,
+		"user": details.User,
+	})
 
-	return client, nil
+	return r.sshPool.AcquireSFTP(ctx, details.Host, details.User, data.SSHPrivateKey.ValueString(), pins, d)
 }
 
-func (r *Resource) createOrUpdateFile(ctx context.Context, resource *ResourceModel, d *diag.Diagnostics) error {
+func (r *Resource) createOrUpdateFile(ctx context.Context, resource *ResourceModel, pins sshutil.HostKeyPins, d *diag.Diagnostics) error {
 	filePath := resource.Path.ValueString()
 	var contents []byte
 
@@ -402,69 +371,63 @@ func (r *Resource) createOrUpdateFile(ctx context.Context, resource *ResourceMod
 		"path": filePath,
 	})
 
-	client, err := r.createSSHClient(ctx, resource, d)
+	sftpClient, err := r.openSFTP(ctx, resource, pins, d)
 	if err != nil {
 		return err
 	}
-	defer func(client *ssh.Client) {
-		if err := client.Close(); err != nil {
-			tflog.Error(ctx, "Failed to close SSH client", map[string]interface{}{"error": err})
-		}
-	}(client)
-
-	// Create an SFTP client
-	sftpClient, err := sftp.NewClient(client)
-	if err != nil {
-		return fmt.Errorf("failed to create SFTP client: %w", err)
-	}
-	defer func(sftpClient *sftp.Client) {
+	defer func() {
 		if err := sftpClient.Close(); err != nil {
-			tflog.Error(ctx, "Failed to close SFTP client", map[string]interface{}{"error": err})
+			tflog.Error(ctx, "Failed to close SFTP session", map[string]interface{}{"error": err})
 		}
-	}(sftpClient)
-
-	// Ensure the directory exists
-	dir := filepath.Dir(filePath)
-	if dir != "." {
-		err = sftpClient.MkdirAll(dir)
-		if err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", dir, err)
-		}
-	}
+	}()
 
-	// Create or truncate the file
-	file, err := sftpClient.Create(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+	// Write the file atomically, so that readers never observe partial
+	// contents
+	if err := sshutil.WriteFileAtomic(sftpClient.Client, filePath, bytes.NewReader(contents), resource.fileAttributes()); err != nil {
+		return err
 	}
-	defer func(file *sftp.File) {
-		if err := file.Close(); err != nil {
-			tflog.Error(ctx, "Failed to close file", map[string]interface{}{"error": err})
-		}
-	}(file)
 
-	// Write the contents to the file
-	_, err = file.Write(contents)
-	if err != nil {
-		return fmt.Errorf("failed to write to file: %w", err)
-	}
+	resource.SHA256 = types.StringValue(hashContents(contents))
 
 	return nil
 }
 
+// fetchContents returns the desired contents of the file; exactly one of the
+// content attributes is set (see ConfigValidators).
 func (r *Resource) fetchContents(ctx context.Context, resource *ResourceModel, d *diag.Diagnostics) ([]byte, error) {
-	if !resource.Contents.IsNull() {
+	switch {
+	case !resource.Contents.IsNull():
 		return []byte(resource.Contents.ValueString()), nil
-	}
 
-	if !resource.ContentsFromURL.IsNull() {
+	case !resource.ContentBase64.IsNull():
+		contents, err := base64.StdEncoding.DecodeString(resource.ContentBase64.ValueString())
+		if err != nil {
+			d.AddAttributeError(path.Root("content_base64"), "Invalid File Contents", "content_base64 is not valid base64: "+err.Error())
+			return nil, err
+		}
+
+		return contents, nil
+
+	case !resource.Source.IsNull():
+		contents, err := os.ReadFile(resource.Source.ValueString())
+		if err != nil {
+			d.AddAttributeError(path.Root("source"), "Invalid File Source", fmt.Sprintf("Could not read %s: %s", resource.Source.ValueString(), err))
+			return nil, err
+		}
+
+		return contents, nil
+
+	case !resource.ContentsFromURL.IsNull():
 		return r.fetchContentFromURL(ctx, resource)
-	}
 
-	d.AddAttributeError(path.Root("contents"), "Missing File Contents", "Either contents or contents_from_url must be specified.")
-	d.AddAttributeError(path.Root("contents_from_url"), "Missing File Contents", "Either contents or contents_from_url must be specified.")
+	default:
+		return nil, fmt.Errorf("no file contents specified")
+	}
+}
 
-	return nil, fmt.Errorf("either contents or contents_from_url must be specified")
+func hashContents(contents []byte) string {
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:])
 }
 
 func (r *Resource) fetchContentFromURL(ctx context.Context, resource *ResourceModel) ([]byte, error) {
@@ -503,53 +466,51 @@ func (r *Resource) fetchContentFromURL(ctx context.Context, resource *ResourceMo
 	return body, nil
 }
 
-func (r *Resource) readFile(ctx context.Context, resource ResourceModel, d *diag.Diagnostics) (bool, string, error) {
+// remoteFile is a file that was read from the remote server.
+type remoteFile struct {
+	contents string
+	sha256   string
+	info     os.FileInfo
+}
+
+// readFile reads a file from the remote server; it returns nil if the file
+// does not exist.
+func (r *Resource) readFile(ctx context.Context, resource ResourceModel, pins sshutil.HostKeyPins, d *diag.Diagnostics) (*remoteFile, error) {
 	filePath := resource.Path.ValueString()
 
 	tflog.Debug(ctx, "Reading remote file", map[string]interface{}{
 		"path": filePath,
 	})
 
-	client, err := r.createSSHClient(ctx, &resource, d)
-	if err != nil {
-		return false, "", err
-	}
-	defer func(client *ssh.Client) {
-		if err := client.Close(); err != nil {
-			tflog.Error(ctx, "Failed to close SSH client", map[string]interface{}{"error": err})
-		}
-	}(client)
-
-	// Create an SFTP client
-	sftpClient, err := sftp.NewClient(client)
+	sftpClient, err := r.openSFTP(ctx, &resource, pins, d)
 	if err != nil {
-		return false, "", fmt.Errorf("failed to create SFTP client: %w", err)
+		return nil, err
 	}
-	defer func(sftpClient *sftp.Client) {
+	defer func() {
 		if err := sftpClient.Close(); err != nil {
-			tflog.Error(ctx, "Failed to close SFTP client", map[string]interface{}{"error": err})
+			tflog.Error(ctx, "Failed to close SFTP session", map[string]interface{}{"error": err})
 		}
-	}(sftpClient)
+	}()
 
 	// Check if the file exists
 	fileInfo, err := sftpClient.Stat(filePath)
 	if err != nil {
 		// File doesn't exist or other error
 		if os.IsNotExist(err) {
-			return false, "", nil
+			return nil, nil
 		}
-		return false, "", fmt.Errorf("failed to check if file exists: %w", err)
+		return nil, fmt.Errorf("failed to check if file exists: %w", err)
 	}
 
 	// Make sure it's a regular file
 	if !fileInfo.Mode().IsRegular() {
-		return false, "", fmt.Errorf("%s is not a regular file", filePath)
+		return nil, fmt.Errorf("%s is not a regular file", filePath)
 	}
 
 	// Open the file for reading
 	file, err := sftpClient.Open(filePath)
 	if err != nil {
-		return false, "", fmt.Errorf("failed to open file: %w", err)
+		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 	defer func(file *sftp.File) {
 		if err := file.Close(); err != nil {
@@ -557,42 +518,43 @@ func (r *Resource) readFile(ctx context.Context, resource ResourceModel, d *diag
 		}
 	}(file)
 
-	// Read the file contents
-	contents, err := io.ReadAll(file)
-	if err != nil {
-		return true, "", fmt.Errorf("failed to read file: %w", err)
+	// Hash the file contents; the contents themselves are only kept if they
+	// are managed as a string
+	hash := sha256.New()
+	result := remoteFile{info: fileInfo}
+
+	if !resource.Contents.IsNull() {
+		contents, err := io.ReadAll(io.TeeReader(file, hash))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+
+		result.contents = string(contents)
+	} else if _, err := io.Copy(hash, file); err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	return true, string(contents), nil
+	result.sha256 = hex.EncodeToString(hash.Sum(nil))
+
+	return &result, nil
 }
 
-func (r *Resource) deleteFile(ctx context.Context, resource ResourceModel, d *diag.Diagnostics) error {
+func (r *Resource) deleteFile(ctx context.Context, resource ResourceModel, pins sshutil.HostKeyPins, d *diag.Diagnostics) error {
 	filePath := resource.Path.ValueString()
 
 	tflog.Debug(ctx, "Deleting remote file", map[string]interface{}{
 		"path": filePath,
 	})
 
-	client, err := r.createSSHClient(ctx, &resource, d)
+	sftpClient, err := r.openSFTP(ctx, &resource, pins, d)
 	if err != nil {
 		return err
 	}
-	defer func(client *ssh.Client) {
-		if err := client.Close(); err != nil {
-			tflog.Error(ctx, "Failed to close SSH client", map[string]interface{}{"error": err})
-		}
-	}(client)
-
-	// Create an SFTP client
-	sftpClient, err := sftp.NewClient(client)
-	if err != nil {
-		return fmt.Errorf("failed to create SFTP client: %w", err)
-	}
-	defer func(sftpClient *sftp.Client) {
+	defer func() {
 		if err := sftpClient.Close(); err != nil {
-			tflog.Error(ctx, "Failed to close SFTP client", map[string]interface{}{"error": err})
+			tflog.Error(ctx, "Failed to close SFTP session", map[string]interface{}{"error": err})
 		}
-	}(sftpClient)
+	}()
 
 	// Check if the file exists before attempting to remove it
 	if _, err := sftpClient.Stat(filePath); err != nil {
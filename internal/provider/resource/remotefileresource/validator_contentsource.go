@@ -0,0 +1,61 @@
+package remotefileresource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ConfigValidator = &exactlyOneOfValidator{}
+
+// exactlyOneOfValidator validates that exactly one of the given (string)
+// attributes is set. Unknown values are counted as set, since they may still
+// turn out to be set during apply.
+type exactlyOneOfValidator struct {
+	attributes []string
+}
+
+func (v *exactlyOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("Exactly one of %s must be specified.", strings.Join(v.attributes, ", "))
+}
+
+func (v *exactlyOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *exactlyOneOfValidator) ValidateResource(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	set := make([]string, 0, len(v.attributes))
+	unknown := false
+
+	for _, attr := range v.attributes {
+		var value types.String
+
+		response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root(attr), &value)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		if value.IsUnknown() {
+			unknown = true
+		}
+
+		if !value.IsNull() {
+			set = append(set, attr)
+		}
+	}
+
+	switch {
+	case len(set) == 0 && !unknown:
+		for _, attr := range v.attributes {
+			response.Diagnostics.AddAttributeError(path.Root(attr), "Missing File Contents", v.Description(ctx))
+		}
+	case len(set) > 1:
+		for _, attr := range set {
+			response.Diagnostics.AddAttributeError(path.Root(attr), "Conflicting File Contents", fmt.Sprintf("%s Got: %s.", v.Description(ctx), strings.Join(set, ", ")))
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package remotefileresource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/mittwald/terraform-provider-mittwald/internal/sshutil"
+)
+
+var _ validator.String = &fileModeValidator{}
+
+// fileModeValidator validates that the value is an octal file mode.
+type fileModeValidator struct{}
+
+func (v *fileModeValidator) Description(_ context.Context) string {
+	return "Validates that the value is an octal file mode, like 0640."
+}
+
+func (v *fileModeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *fileModeValidator) ValidateString(_ context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := sshutil.ParseFileMode(request.ConfigValue.ValueString()); err != nil {
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid file mode", err.Error())
+	}
+}
@@ -0,0 +1,65 @@
+package shellutil
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/alessio/shellescape"
+)
+
+var environmentVariableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// exportedValue matches an `export NAME=value` segment as built by
+// BuildShellCommand; value is either a bare word or a single-quoted string as
+// produced by shellescape.Quote.
+var exportedValue = regexp.MustCompile(`export ([A-Za-z_][A-Za-z0-9_]*)=(?:'[^']*'(?:"'"'[^']*')*|[\w@%+=:,./-]*)`)
+
+// ValidateEnvironmentVariableName returns an error if name cannot be used as a
+// shell environment variable name.
+func ValidateEnvironmentVariableName(name string) error {
+	if !environmentVariableName.MatchString(name) {
+		return fmt.Errorf("%q is not a valid environment variable name", name)
+	}
+
+	return nil
+}
+
+// BuildShellCommand builds a shell command line that changes into workingDir
+// (if not empty), exports the given environment variables and then runs
+// command. The command itself is passed through verbatim, so that it may use
+// shell features like pipes or variable expansion.
+func BuildShellCommand(workingDir string, env map[string]string, command string) (string, error) {
+	parts := make([]string, 0, len(env)+2)
+
+	if workingDir != "" {
+		parts = append(parts, "cd "+shellescape.Quote(workingDir))
+	}
+
+	names := make([]string, 0, len(env))
+	for name := range env {
+		if err := ValidateEnvironmentVariableName(name); err != nil {
+			return "", err
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		parts = append(parts, "export "+name+"="+shellescape.Quote(env[name]))
+	}
+
+	parts = append(parts, command)
+
+	return strings.Join(parts, " && "), nil
+}
+
+// RedactExportedValues replaces the values of all exported environment
+// variables in a command line built by BuildShellCommand, so that the command
+// may be logged without leaking (potentially secret) values.
+func RedactExportedValues(command string) string {
+	return exportedValue.ReplaceAllString(command, "export $1=***")
+}
@@ -0,0 +1,89 @@
+package shellutil
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestBuildShellCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		workingDir  string
+		env         map[string]string
+		command     string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "command only",
+			command:  "wp cache flush",
+			expected: "wp cache flush",
+		},
+		{
+			name:       "working directory",
+			workingDir: "/html/my app",
+			command:    "composer install",
+			expected:   "cd '/html/my app' && composer install",
+		},
+		{
+			name:       "environment variables are sorted and quoted",
+			workingDir: "/html/app",
+			env:        map[string]string{"B": "it's", "A": "1"},
+			command:    "drush updb -y",
+			expected:   "cd /html/app && export A=1 && export B='it'\"'\"'s' && drush updb -y",
+		},
+		{
+			name:        "invalid environment variable name",
+			env:         map[string]string{"FOO; rm -rf /": "x"},
+			command:     "true",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			result, err := BuildShellCommand(tt.workingDir, tt.env, tt.command)
+			if tt.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestRedactExportedValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		expected string
+	}{
+		{
+			name:     "command without environment",
+			command:  "cd /html/app && wp cache flush",
+			expected: "cd /html/app && wp cache flush",
+		},
+		{
+			name:     "bare and quoted values",
+			command:  "cd /html/app && export A=1 && export B='it'\"'\"'s' && export C='' && drush updb -y",
+			expected: "cd /html/app && export A=*** && export B=*** && export C=*** && drush updb -y",
+		},
+		{
+			name:     "quoted value containing an export",
+			command:  "export A='x && export B=y' && true",
+			expected: "export A=*** && true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(RedactExportedValues(tt.command)).To(Equal(tt.expected))
+		})
+	}
+}
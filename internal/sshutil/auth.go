@@ -0,0 +1,118 @@
+package sshutil
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// DefaultPrivateKeyPaths are the paths of the private keys that are tried when
+// no private key is given explicitly, relative to the user's home directory.
+var DefaultPrivateKeyPaths = []string{".ssh/id_ed25519", ".ssh/id_rsa"}
+
+// DefaultAuthDescription describes the authentication methods that are used
+// when no private key is given explicitly, for use in schema descriptions.
+const DefaultAuthDescription = "an SSH agent (via `SSH_AUTH_SOCK`) and the default private keys `~/.ssh/id_ed25519` and `~/.ssh/id_rsa` are used"
+
+// authMethods determines the SSH authentication methods to use. If privateKey
+// is set, only that key is used. Otherwise, the keys offered by an SSH agent
+// (if SSH_AUTH_SOCK is set) and the default private keys (if they exist) are
+// tried in that order.
+//
+// The returned cleanup function must be called after the SSH handshake; it
+// closes the connection to the SSH agent.
+func authMethods(cfg Config, privateKey string, d *diag.Diagnostics) ([]ssh.AuthMethod, func(), error) {
+	if privateKey != "" {
+		signer, err := parsePrivateKey([]byte(privateKey), cfg.PrivateKeyPassphrase)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return []ssh.AuthMethod{ssh.PublicKeys(signer)}, func() {}, nil
+	}
+
+	var methods []ssh.AuthMethod
+	cleanup := func() {}
+
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			d.AddWarning("Could not connect to SSH agent", fmt.Sprintf("Could not connect to the SSH agent at %s (from SSH_AUTH_SOCK): %s", socket, err))
+		} else {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+			cleanup = func() { _ = conn.Close() }
+		}
+	}
+
+	signers, err := defaultSigners(cfg, d)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+
+	if len(methods) == 0 {
+		cleanup()
+		return nil, nil, fmt.Errorf("no SSH private key was specified, no SSH agent is available, and none of the default private keys (%v) exist", DefaultPrivateKeyPaths)
+	}
+
+	return methods, cleanup, nil
+}
+
+func defaultSigners(cfg Config, d *diag.Diagnostics) ([]ssh.Signer, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("could not get user home directory: %w", err)
+	}
+
+	var signers []ssh.Signer
+
+	for _, p := range DefaultPrivateKeyPaths {
+		keyPath := filepath.Join(homeDir, p)
+
+		keyBytes, err := os.ReadFile(keyPath)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("unable to read default private key %s: %w", keyPath, err)
+		}
+
+		signer, err := parsePrivateKey(keyBytes, cfg.PrivateKeyPassphrase)
+		if err != nil {
+			d.AddWarning("Could not use default SSH private key", fmt.Sprintf("Skipping private key %s: %s", keyPath, err))
+			continue
+		}
+
+		signers = append(signers, signer)
+	}
+
+	return signers, nil
+}
+
+func parsePrivateKey(key []byte, passphrase string) (ssh.Signer, error) {
+	signer, err := ssh.ParsePrivateKey(key)
+
+	var missingErr *ssh.PassphraseMissingError
+	if errors.As(err, &missingErr) {
+		if passphrase == "" {
+			return nil, errors.New("private key is encrypted, but no passphrase was configured; set the ssh_private_key_passphrase provider attribute, or use an SSH agent")
+		}
+
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key: %w", err)
+	}
+
+	return signer, nil
+}
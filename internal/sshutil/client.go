@@ -0,0 +1,54 @@
+package sshutil
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"golang.org/x/crypto/ssh"
+)
+
+// Dial opens an SSH connection to the given host (on port 22). If privateKey
+// is set, it is used for authentication; otherwise, an SSH agent and the
+// default private keys are used (see DefaultPrivateKeyPaths).
+//
+// The host key is verified using HostKeyCallback (checking it against pins,
+// which may be nil), and returned, so that it can be pinned; warnings (for example, about unusable
+// private keys) are added to d.
+func Dial(ctx context.Context, cfg Config, host, user, privateKey string, pins HostKeyPins, d *diag.Diagnostics) (*ssh.Client, HostKey, error) {
+	var hostKey HostKey
+
+	auth, cleanup, err := authMethods(cfg, privateKey, d)
+	if err != nil {
+		return nil, hostKey, err
+	}
+	defer cleanup()
+
+	hostKeyCallback, err := HostKeyCallback(cfg, pins, &hostKey)
+	if err != nil {
+		return nil, hostKey, err
+	}
+
+	config := &ssh.ClientConfig{
+		User:            user,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+	}
+
+	addr := net.JoinHostPort(host, "22")
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, hostKey, fmt.Errorf("failed to dial: %w", err)
+	}
+
+	clientConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, hostKey, fmt.Errorf("failed to dial: %w", err)
+	}
+
+	return ssh.NewClient(clientConn, chans, reqs), hostKey, nil
+}
@@ -0,0 +1,37 @@
+package sshutil
+
+import "time"
+
+const (
+	// DefaultIdleTimeout is the default duration after which unused pooled
+	// connections are closed.
+	DefaultIdleTimeout = 30 * time.Second
+
+	// DefaultMaxConcurrentSessions is the default number of SSH operations that
+	// may run concurrently.
+	DefaultMaxConcurrentSessions = 8
+)
+
+// Config contains provider-wide SSH settings.
+type Config struct {
+	// KnownHostsFile is the path of an OpenSSH known_hosts file that is
+	// consulted (in addition to the built-in host keys of the mittwald
+	// clusters) to verify host keys.
+	KnownHostsFile string
+
+	// StrictHostKeyChecking causes connections to hosts with unknown host keys
+	// to be refused; otherwise, only a warning is emitted.
+	StrictHostKeyChecking bool
+
+	// PrivateKeyPassphrase is used to decrypt encrypted private keys.
+	PrivateKeyPassphrase string
+
+	// IdleTimeout is the duration after which unused pooled connections are
+	// closed; defaults to DefaultIdleTimeout.
+	IdleTimeout time.Duration
+
+	// MaxConcurrentSessions limits the number of SSH operations that may run
+	// concurrently across all connections of a pool; defaults to
+	// DefaultMaxConcurrentSessions.
+	MaxConcurrentSessions int
+}
@@ -0,0 +1,165 @@
+package sshutil
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// OutputStream identifies the stream that a line of command output was
+// written to.
+type OutputStream string
+
+const (
+	Stdout OutputStream = "stdout"
+	Stderr OutputStream = "stderr"
+)
+
+// OutputHandler is called for each line of output of a remote command. It may
+// be called concurrently for stdout and stderr.
+type OutputHandler func(stream OutputStream, line string)
+
+// RunCommand runs the given command in a new session on the SSH client, and
+// passes its output line by line to onOutput. stdin may be nil.
+//
+// If the command exits with a non-zero exit status, an *ssh.ExitError is
+// returned. When ctx is cancelled, the remote command is killed and the
+// context's error is returned.
+func RunCommand(ctx context.Context, client *ssh.Client, command string, stdin io.Reader, onOutput OutputHandler) error {
+	return runCommand(ctx, client, command, stdin, nil, onOutput)
+}
+
+// StreamCommand is like RunCommand, but copies the (possibly binary) standard
+// output of the command to stdout; only the lines of the standard error are
+// passed to onOutput.
+func StreamCommand(ctx context.Context, client *ssh.Client, command string, stdout io.Writer, onOutput OutputHandler) error {
+	return runCommand(ctx, client, command, nil, stdout, onOutput)
+}
+
+func runCommand(ctx context.Context, client *ssh.Client, command string, stdin io.Reader, stdoutWriter io.Writer, onOutput OutputHandler) error {
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to create SSH session: %w", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open stdout: %w", err)
+	}
+
+	stderr, err := session.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open stderr: %w", err)
+	}
+
+	if stdin != nil {
+		session.Stdin = stdin
+	}
+
+	if err := session.Start(command); err != nil {
+		return fmt.Errorf("failed to start command: %w", err)
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	var copyErr error
+
+	go func() {
+		defer wg.Done()
+
+		if stdoutWriter == nil {
+			scanLines(stdout, Stdout, onOutput)
+			return
+		}
+
+		if _, err := io.Copy(stdoutWriter, stdout); err != nil {
+			copyErr = fmt.Errorf("failed to write command output: %w", err)
+			_ = session.Signal(ssh.SIGKILL)
+			_, _ = io.Copy(io.Discard, stdout)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		scanLines(stderr, Stderr, onOutput)
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		wg.Wait()
+
+		err := session.Wait()
+		if copyErr != nil {
+			err = copyErr
+		}
+
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		_ = session.Signal(ssh.SIGKILL)
+		_ = session.Close()
+		return ctx.Err()
+	}
+}
+
+func scanLines(r io.Reader, stream OutputStream, onOutput OutputHandler) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if onOutput != nil {
+			onOutput(stream, scanner.Text())
+		}
+	}
+
+	// Drain the remaining output (for example, after a line exceeding the
+	// maximum buffer size), so that the remote command does not block.
+	_, _ = io.Copy(io.Discard, r)
+}
+
+// OutputTail collects the last lines of a command's output; it is safe for
+// concurrent use and can be used as an OutputHandler via its Add method.
+type OutputTail struct {
+	mu    sync.Mutex
+	max   int
+	lines []string
+}
+
+// NewOutputTail creates an OutputTail that retains at most maxLines lines.
+func NewOutputTail(maxLines int) *OutputTail {
+	return &OutputTail{max: maxLines}
+}
+
+// Add records a line of output.
+func (t *OutputTail) Add(stream OutputStream, line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if stream == Stderr {
+		line = "[stderr] " + line
+	}
+
+	t.lines = append(t.lines, line)
+	if len(t.lines) > t.max {
+		t.lines = t.lines[len(t.lines)-t.max:]
+	}
+}
+
+// String returns the retained lines, separated by newlines.
+func (t *OutputTail) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return strings.Join(t.lines, "\n")
+}
@@ -0,0 +1,57 @@
+package sshutil
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestOutputTail(t *testing.T) {
+	g := NewWithT(t)
+
+	tail := NewOutputTail(3)
+	tail.Add(Stdout, "one")
+	tail.Add(Stderr, "two")
+
+	g.Expect(tail.String()).To(Equal("one\n[stderr] two"))
+
+	tail.Add(Stdout, "three")
+	tail.Add(Stdout, "four")
+
+	g.Expect(tail.String()).To(Equal("[stderr] two\nthree\nfour"))
+}
+
+func TestStreamCommand(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server := startTestServer(t)
+
+	pool := newPool(Config{}, server.dial)
+	defer func() { _ = pool.Close() }()
+
+	lease, err := pool.Acquire(ctx, "host", "user", "", nil, &diag.Diagnostics{})
+	g.Expect(err).NotTo(HaveOccurred())
+	defer lease.Release()
+
+	tail := NewOutputTail(10)
+	stdout := strings.Builder{}
+
+	err = StreamCommand(ctx, lease.Client, "dump", &stdout, tail.Add)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(stdout.String()).To(Equal("dump\n"))
+	g.Expect(tail.String()).To(BeEmpty())
+
+	stdout.Reset()
+
+	err = StreamCommand(ctx, lease.Client, "fail", &stdout, tail.Add)
+
+	var exitErr *ssh.ExitError
+	g.Expect(errors.As(err, &exitErr)).To(BeTrue())
+	g.Expect(stdout.String()).To(BeEmpty())
+	g.Expect(tail.String()).To(Equal("[stderr] something went wrong"))
+}
@@ -0,0 +1,110 @@
+package sshutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"golang.org/x/crypto/ssh"
+)
+
+// HostKeyPins maps hosts to the host keys (in authorized_keys format) that
+// were trusted on first use. Resources keep their pins in the private state,
+// so that a changed host key is detected on subsequent connections.
+type HostKeyPins map[string]string
+
+// ParseHostKeyPins decodes pins that were encoded with HostKeyPins.Bytes. An
+// empty input results in an empty set of pins.
+func ParseHostKeyPins(raw []byte) (HostKeyPins, error) {
+	pins := make(HostKeyPins)
+
+	if len(raw) == 0 {
+		return pins, nil
+	}
+
+	if err := json.Unmarshal(raw, &pins); err != nil {
+		return nil, fmt.Errorf("could not decode SSH host key pins: %w", err)
+	}
+
+	return pins, nil
+}
+
+// Bytes encodes the pins, for storing them in the private state.
+func (p HostKeyPins) Bytes() []byte {
+	raw, _ := json.Marshal(p)
+	return raw
+}
+
+// Check checks the host key that was presented by host against the pinned
+// key, without changing the pins. An unverified key is refused in strict mode
+// (even if it was pinned before strict mode was enabled), and if it differs
+// from the pinned key. Unlike Verify, Check can be used while connecting, so
+// that a refused host never sees any authentication attempt.
+func (p HostKeyPins) Check(host string, key HostKey, strict bool) error {
+	if key.Verified {
+		return nil
+	}
+
+	fingerprint := ssh.FingerprintSHA256(key.Key)
+
+	if strict {
+		return fmt.Errorf("the host key for %s (%s) is not known, and strict host key checking is enabled; add the host key to your known hosts file", host, fingerprint)
+	}
+
+	pinned, ok := p[host]
+	if ok && pinned != strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key.Key))) {
+		pinnedFingerprint := pinned
+		if pinnedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pinned)); err == nil {
+			pinnedFingerprint = ssh.FingerprintSHA256(pinnedKey)
+		}
+
+		return fmt.Errorf("the host key for %s has changed from %s to %s since the first connection; this might indicate a man-in-the-middle attack. If the host key was rotated legitimately, verify the new fingerprint, and add the host key to your known hosts file (see the ssh_known_hosts_file provider attribute)", host, pinnedFingerprint, fingerprint)
+	}
+
+	return nil
+}
+
+// Verify checks the host key that was presented by host (see Check), and
+// updates the pins:
+//
+//   - If no key is pinned for the host yet, the key is pinned.
+//   - If the pinned key differs and the new key is verified, the pin is
+//     updated and a warning is added to d.
+//
+// If p is nil (for example, for actions, which have no state to keep pins in),
+// unverified keys are accepted with a warning, unless in strict mode.
+func (p HostKeyPins) Verify(host string, key HostKey, strict bool, d *diag.Diagnostics) error {
+	if err := p.Check(host, key, strict); err != nil {
+		return err
+	}
+
+	presented := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key.Key)))
+	fingerprint := ssh.FingerprintSHA256(key.Key)
+
+	pinned, ok := p[host]
+	if ok && pinned == presented {
+		return nil
+	}
+
+	if ok {
+		pinnedFingerprint := pinned
+		if pinnedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pinned)); err == nil {
+			pinnedFingerprint = ssh.FingerprintSHA256(pinnedKey)
+		}
+
+		d.AddWarning("SSH host key changed", fmt.Sprintf("The host key for %s has changed from %s to %s. The new host key was verified using the known hosts, and has been pinned instead of the old one.", host, pinnedFingerprint, fingerprint))
+		p[host] = presented
+		return nil
+	}
+
+	if p == nil {
+		if !key.Verified {
+			d.AddWarning("Unknown SSH host key", fmt.Sprintf("The host key for %s (%s) is not known, and could not be verified. You can add the host key to a known hosts file, and configure it using the ssh_known_hosts_file provider attribute.", host, fingerprint))
+		}
+		return nil
+	}
+
+	p[host] = presented
+	return nil
+}
@@ -2,21 +2,28 @@ package sshutil
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"golang.org/x/crypto/ssh"
 	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
 // knownHostsKeys is a map of host to their public keys in SSH wire format.
 //
-// This map contains all currently known host keys for the mittwald platform.
-// These are very unlikely to change, but if they do, the provider will need to
-// be updated accordingly. Also, new clusters may be added in the future.
+// This map contains the host keys of the mittwald clusters that were known
+// when this provider version was released; these hosts are verified even on
+// the first connection. Hosts that are not listed here (for example, new
+// clusters) are trusted on first use and pinned in the state of the resources
+// that connect to them (see HostKeyPins), so they work without upgrading the
+// provider.
 //
-// Alternatively, it would be nice to have an API endpoint to retrieve the
-// known host keys, but currently there is no such endpoint available in the
-// mittwald API. Therefore, we hardcode the known host keys here.
+// Ideally, the host keys would be retrieved from an API endpoint, but
+// currently there is no such endpoint available in the mittwald API.
 //
 // The format is the same as found in ~/.ssh/known_hosts but without the
 // hostname prefix.
@@ -30,31 +37,100 @@ var knownHostsKeys = map[string]string{
 	"ssh.isenstedt.project.host:22":   "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDA4BkiBXadL4ZCixqcOywUp+l4RnzNEtTTC+Gr+w1dQkXuGg5b6RGJmu+KodFgMyOTPwQMnhj0y0ZQKeHSQVQ4xYLO4kAZNc5AgGPuR9a1cozdLisL8E52fl6YP0ytqOtuH/hsKoIskz1Zl8xUP6mtgVqOT3sZtG29kh3JhngP+JBw94yUs0bOIO84ZPpFbEQ9hmkHMrkHgVoCYpgbV5hnY7tOSyKxWVEQChgXwWe11vpmZzv4XZtnP39bwLbiy4mnOkGqLreXb7kCAljF9hqCOyTaC+mSDdAMsM+qdy7A4SHj6RqCd77QHkmzHJ9gBUnGNX8xMN7+9Rlz3qxK6bqD",
 }
 
-// VerifyKnownClusters checks if the provided SSH public key matches the known
-// host key for the given hostname.
-func VerifyKnownClusters(d *diag.Diagnostics) ssh.HostKeyCallback {
-	return func(hostname string, _ net.Addr, key ssh.PublicKey) error {
-		// Get the expected key for this host
-		expectedKeyStr, exists := knownHostsKeys[hostname]
-		if !exists {
-			d.AddWarning("Unknown SSH host key", fmt.Sprintf("The host key for %s is not known. The reason for this might be that you are using an outdated version of the mittwald Terraform provider. Please upgrade to a recent version, or open an issue at https://github.com/mittwald/terraform-provider-mittwald if the issue persists.", hostname))
-			// Log a warning but do not fail the connection
-			// If the host is not in our known hosts, we cannot verify it;
-			// accept the host key, in this case.
-			return nil
+// HostKey is the host key that was presented by a server.
+type HostKey struct {
+	Key ssh.PublicKey
+
+	// Verified is set if the key was verified using the known hosts file or
+	// the built-in host keys of the mittwald clusters.
+	Verified bool
+}
+
+// HostKeyCallback returns a host key callback that verifies host keys against
+// the known_hosts file from the configuration (if any) and the built-in host
+// keys of the mittwald clusters, in that order. A key mismatch always causes
+// the connection to be refused.
+//
+// Keys that could not be verified this way are checked against pins (which
+// may be nil) before authenticating, and refused in strict mode (see
+// HostKeyPins.Check). The presented key is stored in observed, so that it can
+// be pinned after connecting (see HostKeyPins.Verify).
+func HostKeyCallback(cfg Config, pins HostKeyPins, observed *HostKey) (ssh.HostKeyCallback, error) {
+	var fileCallback ssh.HostKeyCallback
+
+	if cfg.KnownHostsFile != "" {
+		knownHostsFile, err := expandHome(cfg.KnownHostsFile)
+		if err != nil {
+			return nil, err
+		}
+
+		fileCallback, err = knownhosts.New(knownHostsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load known hosts file %s: %w", knownHostsFile, err)
+		}
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		*observed = HostKey{Key: key}
+
+		if fileCallback != nil {
+			err := fileCallback(hostname, remote, key)
+
+			var keyErr *knownhosts.KeyError
+			if err == nil {
+				observed.Verified = true
+				return nil
+			} else if !errors.As(err, &keyErr) || len(keyErr.Want) > 0 {
+				return fmt.Errorf("host key verification failed for %s: %w", hostname, err)
+			}
 		}
 
-		// Parse the expected key
-		expectedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(expectedKeyStr))
+		known, err := verifyKnownCluster(hostname, key)
 		if err != nil {
-			return fmt.Errorf("failed to parse expected host key: %w", err)
+			return err
 		}
 
-		// Compare the keys
-		if !bytes.Equal(key.Marshal(), expectedKey.Marshal()) {
-			return fmt.Errorf("host key verification failed for %s", hostname)
+		observed.Verified = known
+
+		host, _, err := net.SplitHostPort(hostname)
+		if err != nil {
+			host = hostname
 		}
 
-		return nil
+		return pins.Check(host, *observed, cfg.StrictHostKeyChecking)
+	}, nil
+}
+
+// verifyKnownCluster checks if the provided SSH public key matches the
+// built-in host key for the given hostname. It returns false if the host is
+// not known.
+func verifyKnownCluster(hostname string, key ssh.PublicKey) (bool, error) {
+	expectedKeyStr, exists := knownHostsKeys[hostname]
+	if !exists {
+		return false, nil
+	}
+
+	expectedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(expectedKeyStr))
+	if err != nil {
+		return false, fmt.Errorf("failed to parse expected host key: %w", err)
+	}
+
+	if !bytes.Equal(key.Marshal(), expectedKey.Marshal()) {
+		return false, fmt.Errorf("host key verification failed for %s", hostname)
+	}
+
+	return true, nil
+}
+
+func expandHome(p string) (string, error) {
+	if !strings.HasPrefix(p, "~/") {
+		return p, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get user home directory: %w", err)
 	}
+
+	return filepath.Join(homeDir, p[2:]), nil
 }
@@ -0,0 +1,235 @@
+package sshutil
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func generateHostKey(t *testing.T) ssh.PublicKey {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func authorizedKey(key ssh.PublicKey) string {
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+}
+
+func TestHostKeyCallback(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 22}
+
+	knownKey := generateHostKey(t)
+	otherKey := generateHostKey(t)
+
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{"ssh.example.project.host:22"}, knownKey) + "\n"
+	if err := os.WriteFile(knownHostsFile, []byte(line), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		cfg            Config
+		pins           HostKeyPins
+		hostname       string
+		key            ssh.PublicKey
+		expectError    bool
+		expectVerified bool
+	}{
+		{
+			name:           "host from known hosts file",
+			cfg:            Config{KnownHostsFile: knownHostsFile, StrictHostKeyChecking: true},
+			hostname:       "ssh.example.project.host:22",
+			key:            knownKey,
+			expectVerified: true,
+		},
+		{
+			name:        "mismatching key from known hosts file",
+			cfg:         Config{KnownHostsFile: knownHostsFile},
+			hostname:    "ssh.example.project.host:22",
+			key:         otherKey,
+			expectError: true,
+		},
+		{
+			name:        "mismatching key for built-in cluster",
+			cfg:         Config{},
+			hostname:    "ssh.fiestel.project.host:22",
+			key:         otherKey,
+			expectError: true,
+		},
+		{
+			name:     "unknown host",
+			cfg:      Config{KnownHostsFile: knownHostsFile},
+			hostname: "ssh.unknown.project.host:22",
+			key:      otherKey,
+		},
+		{
+			name:        "unknown host in strict mode",
+			cfg:         Config{KnownHostsFile: knownHostsFile, StrictHostKeyChecking: true},
+			hostname:    "ssh.unknown.project.host:22",
+			key:         otherKey,
+			expectError: true,
+		},
+		{
+			name:     "unknown host with pinned key",
+			cfg:      Config{},
+			pins:     HostKeyPins{"ssh.unknown.project.host": authorizedKey(knownKey)},
+			hostname: "ssh.unknown.project.host:22",
+			key:      knownKey,
+		},
+		{
+			name:        "unknown host with pinned key in strict mode",
+			cfg:         Config{StrictHostKeyChecking: true},
+			pins:        HostKeyPins{"ssh.unknown.project.host": authorizedKey(knownKey)},
+			hostname:    "ssh.unknown.project.host:22",
+			key:         knownKey,
+			expectError: true,
+		},
+		{
+			name:        "unknown host with different pinned key",
+			cfg:         Config{},
+			pins:        HostKeyPins{"ssh.unknown.project.host": authorizedKey(knownKey)},
+			hostname:    "ssh.unknown.project.host:22",
+			key:         otherKey,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			observed := HostKey{}
+
+			callback, err := HostKeyCallback(tt.cfg, tt.pins, &observed)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			err = callback(tt.hostname, addr, tt.key)
+			if tt.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			g.Expect(observed.Key).To(Equal(tt.key))
+			g.Expect(observed.Verified).To(Equal(tt.expectVerified))
+		})
+	}
+}
+
+func TestHostKeyPinsVerify(t *testing.T) {
+	pinnedKey := generateHostKey(t)
+	otherKey := generateHostKey(t)
+
+	const host = "ssh.example.project.host"
+
+	pinned := func() HostKeyPins {
+		pins := make(HostKeyPins)
+		g := NewWithT(t)
+		g.Expect(pins.Verify(host, HostKey{Key: pinnedKey}, false, &diag.Diagnostics{})).To(Succeed())
+		return pins
+	}
+
+	tests := []struct {
+		name          string
+		pins          HostKeyPins
+		key           HostKey
+		strict        bool
+		expectError   bool
+		expectWarning bool
+		expectPinned  ssh.PublicKey
+	}{
+		{
+			name:         "pinned key",
+			pins:         pinned(),
+			key:          HostKey{Key: pinnedKey},
+			expectPinned: pinnedKey,
+		},
+		{
+			name:         "pinned key that is verified in strict mode",
+			pins:         pinned(),
+			key:          HostKey{Key: pinnedKey, Verified: true},
+			strict:       true,
+			expectPinned: pinnedKey,
+		},
+		{
+			name:         "pinned key that is not verified in strict mode",
+			pins:         pinned(),
+			key:          HostKey{Key: pinnedKey},
+			strict:       true,
+			expectError:  true,
+			expectPinned: pinnedKey,
+		},
+		{
+			name:         "changed key",
+			pins:         pinned(),
+			key:          HostKey{Key: otherKey},
+			expectError:  true,
+			expectPinned: pinnedKey,
+		},
+		{
+			name:          "changed key that is verified",
+			pins:          pinned(),
+			key:           HostKey{Key: otherKey, Verified: true},
+			expectWarning: true,
+			expectPinned:  otherKey,
+		},
+		{
+			name:         "trust on first use",
+			pins:         HostKeyPins{},
+			key:          HostKey{Key: otherKey},
+			expectPinned: otherKey,
+		},
+		{
+			name:        "unverified key in strict mode",
+			pins:        HostKeyPins{},
+			key:         HostKey{Key: otherKey},
+			strict:      true,
+			expectError: true,
+		},
+		{
+			name:          "unverified key without pins",
+			key:           HostKey{Key: otherKey},
+			expectWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			d := diag.Diagnostics{}
+
+			err := tt.pins.Verify(host, tt.key, tt.strict, &d)
+			if tt.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			g.Expect(d.WarningsCount() > 0).To(Equal(tt.expectWarning))
+
+			if tt.expectPinned != nil {
+				roundTripped, err := ParseHostKeyPins(tt.pins.Bytes())
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(roundTripped[host]).To(Equal(authorizedKey(tt.expectPinned)))
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package sshutil
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"golang.org/x/crypto/ssh"
+)
+
+// DialFunc opens a new SSH connection; see Dial.
+type DialFunc func(ctx context.Context, host, user, privateKey string, pins HostKeyPins, d *diag.Diagnostics) (*ssh.Client, HostKey, error)
+
+type poolKey struct {
+	host       string
+	user       string
+	privateKey string
+}
+
+type pooledConn struct {
+	client    *ssh.Client
+	hostKey   HostKey
+	leases    int
+	idleTimer *time.Timer
+}
+
+// Pool is a pool of SSH connections, keyed by host and user (and private key).
+// Connections are shared between concurrent operations and closed after they
+// have been unused for the configured idle timeout. The number of concurrent
+// operations is limited by the configured maximum.
+//
+// A Pool is scoped to a provider instance, and is safe for concurrent use.
+type Pool struct {
+	dial        DialFunc
+	idleTimeout time.Duration
+	strict      bool
+	sem         chan struct{}
+
+	mu    sync.Mutex
+	conns map[poolKey]*pooledConn
+}
+
+// NewPool creates a new connection pool that opens connections using Dial.
+func NewPool(cfg Config) *Pool {
+	return newPool(cfg, func(ctx context.Context, host, user, privateKey string, pins HostKeyPins, d *diag.Diagnostics) (*ssh.Client, HostKey, error) {
+		return Dial(ctx, cfg, host, user, privateKey, pins, d)
+	})
+}
+
+func newPool(cfg Config, dial DialFunc) *Pool {
+	idleTimeout := cfg.IdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultIdleTimeout
+	}
+
+	maxSessions := cfg.MaxConcurrentSessions
+	if maxSessions <= 0 {
+		maxSessions = DefaultMaxConcurrentSessions
+	}
+
+	return &Pool{
+		dial:        dial,
+		idleTimeout: idleTimeout,
+		strict:      cfg.StrictHostKeyChecking,
+		sem:         make(chan struct{}, maxSessions),
+		conns:       make(map[poolKey]*pooledConn),
+	}
+}
+
+// Lease grants the use of a pooled SSH connection. It must be released after
+// use; the connection itself must not be closed.
+type Lease struct {
+	Client *ssh.Client
+
+	release sync.Once
+	pool    *Pool
+	key     poolKey
+	conn    *pooledConn
+}
+
+// Release returns the connection to the pool. It is safe to call Release
+// multiple times.
+func (l *Lease) Release() {
+	l.release.Do(func() {
+		l.pool.release(l.key, l.conn)
+		<-l.pool.sem
+	})
+}
+
+// discard removes the connection from the pool and closes it, instead of
+// returning it to the pool; it is used for connections whose host key was
+// refused.
+func (l *Lease) discard() {
+	l.release.Do(func() {
+		l.pool.discard(l.key, l.conn)
+		<-l.pool.sem
+	})
+}
+
+// Acquire leases a connection to the given host, opening a new connection if
+// there is no pooled connection yet. If the maximum number of concurrent
+// operations is reached, Acquire blocks until another lease is released or ctx
+// is cancelled.
+//
+// The host key of the connection is verified against pins (which may be nil;
+// see HostKeyPins.Verify); newly pinned keys are added to pins. New
+// connections are already checked before authenticating; pooled connections
+// are checked again, since they might have been opened for other pins. If the
+// host key is refused, the connection is closed.
+func (p *Pool) Acquire(ctx context.Context, host, user, privateKey string, pins HostKeyPins, d *diag.Diagnostics) (*Lease, error) {
+	select {
+	case p.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	key := poolKey{host: host, user: user, privateKey: fingerprint(privateKey)}
+
+	conn, err := p.connection(ctx, key, host, user, privateKey, pins, d)
+	if err != nil {
+		<-p.sem
+		return nil, err
+	}
+
+	lease := &Lease{Client: conn.client, pool: p, key: key, conn: conn}
+
+	if err := pins.Verify(host, conn.hostKey, p.strict, d); err != nil {
+		lease.discard()
+		return nil, err
+	}
+
+	return lease, nil
+}
+
+func (p *Pool) connection(ctx context.Context, key poolKey, host, user, privateKey string, pins HostKeyPins, d *diag.Diagnostics) (*pooledConn, error) {
+	if conn := p.lease(key); conn != nil {
+		return conn, nil
+	}
+
+	client, hostKey, err := p.dial(ctx, host, user, privateKey, pins, d)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Another operation might have opened a connection in the meantime; in
+	// that case, use that one instead.
+	if conn, ok := p.conns[key]; ok {
+		_ = client.Close()
+		conn.acquire()
+		return conn, nil
+	}
+
+	conn := &pooledConn{client: client, hostKey: hostKey, leases: 1}
+	p.conns[key] = conn
+
+	go func() {
+		_ = client.Wait()
+		p.forget(key, conn)
+	}()
+
+	return conn, nil
+}
+
+func (p *Pool) lease(key poolKey) *pooledConn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conn, ok := p.conns[key]
+	if !ok {
+		return nil
+	}
+
+	conn.acquire()
+	return conn
+}
+
+func (c *pooledConn) acquire() {
+	c.leases++
+	if c.idleTimer != nil {
+		c.idleTimer.Stop()
+		c.idleTimer = nil
+	}
+}
+
+func (p *Pool) release(key poolKey, conn *pooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conn.leases--
+	if conn.leases > 0 {
+		return
+	}
+
+	conn.idleTimer = time.AfterFunc(p.idleTimeout, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		if conn.leases == 0 && p.conns[key] == conn {
+			delete(p.conns, key)
+			_ = conn.client.Close()
+		}
+	})
+}
+
+func (p *Pool) discard(key poolKey, conn *pooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conn.leases--
+	if p.conns[key] == conn {
+		delete(p.conns, key)
+	}
+
+	_ = conn.client.Close()
+}
+
+// forget removes a connection that was closed (for example, by the server)
+// from the pool.
+func (p *Pool) forget(key poolKey, conn *pooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conns[key] == conn {
+		delete(p.conns, key)
+	}
+}
+
+// Close closes all pooled connections.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+	for key, conn := range p.conns {
+		if conn.idleTimer != nil {
+			conn.idleTimer.Stop()
+		}
+
+		errs = append(errs, conn.client.Close())
+		delete(p.conns, key)
+	}
+
+	return errors.Join(errs...)
+}
+
+func fingerprint(privateKey string) string {
+	if privateKey == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(privateKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,199 @@
+package sshutil
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestPoolReusesConnections(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server := startTestServer(t)
+
+	pool := newPool(Config{IdleTimeout: time.Minute}, server.dial)
+	defer func() { _ = pool.Close() }()
+
+	first, err := pool.Acquire(ctx, "host", "user", "", nil, &diag.Diagnostics{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	second, err := pool.Acquire(ctx, "host", "user", "", nil, &diag.Diagnostics{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(second.Client).To(BeIdenticalTo(first.Client))
+
+	first.Release()
+	second.Release()
+
+	third, err := pool.Acquire(ctx, "host", "other-user", "", nil, &diag.Diagnostics{})
+	g.Expect(err).NotTo(HaveOccurred())
+	defer third.Release()
+
+	g.Expect(third.Client).NotTo(BeIdenticalTo(first.Client))
+	g.Expect(server.connections.Load()).To(BeEquivalentTo(2))
+}
+
+func TestPoolClosesIdleConnections(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server := startTestServer(t)
+
+	pool := newPool(Config{IdleTimeout: 10 * time.Millisecond}, server.dial)
+	defer func() { _ = pool.Close() }()
+
+	lease, err := pool.Acquire(ctx, "host", "user", "", nil, &diag.Diagnostics{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	client := lease.Client
+	lease.Release()
+
+	g.Eventually(func() error {
+		_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+		return err
+	}).Should(HaveOccurred())
+
+	lease, err = pool.Acquire(ctx, "host", "user", "", nil, &diag.Diagnostics{})
+	g.Expect(err).NotTo(HaveOccurred())
+	defer lease.Release()
+
+	g.Expect(lease.Client).NotTo(BeIdenticalTo(client))
+	g.Expect(server.connections.Load()).To(BeEquivalentTo(2))
+}
+
+func TestPoolLimitsConcurrentSessions(t *testing.T) {
+	g := NewWithT(t)
+	server := startTestServer(t)
+
+	pool := newPool(Config{MaxConcurrentSessions: 1}, server.dial)
+	defer func() { _ = pool.Close() }()
+
+	lease, err := pool.Acquire(context.Background(), "host", "user", "", nil, &diag.Diagnostics{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = pool.Acquire(ctx, "host", "user", "", nil, &diag.Diagnostics{})
+	g.Expect(err).To(MatchError(context.DeadlineExceeded))
+
+	lease.Release()
+
+	lease, err = pool.Acquire(context.Background(), "host", "user", "", nil, &diag.Diagnostics{})
+	g.Expect(err).NotTo(HaveOccurred())
+	lease.Release()
+}
+
+func TestPoolVerifiesHostKeyPins(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	pins := make(HostKeyPins)
+
+	pool := newPool(Config{}, startTestServer(t).dial)
+	defer func() { _ = pool.Close() }()
+
+	lease, err := pool.Acquire(ctx, "host", "user", "", pins, &diag.Diagnostics{})
+	g.Expect(err).NotTo(HaveOccurred())
+	lease.Release()
+
+	g.Expect(pins).To(HaveKey("host"))
+
+	// A server with a different host key, pretending to be the same host
+	otherPool := newPool(Config{}, startTestServer(t).dial)
+	defer func() { _ = otherPool.Close() }()
+
+	_, err = otherPool.Acquire(ctx, "host", "user", "", pins, &diag.Diagnostics{})
+	g.Expect(err).To(MatchError(ContainSubstring("has changed")))
+	g.Expect(otherPool.conns).To(BeEmpty())
+
+	strictPool := newPool(Config{StrictHostKeyChecking: true}, startTestServer(t).dial)
+	defer func() { _ = strictPool.Close() }()
+
+	_, err = strictPool.Acquire(ctx, "host", "user", "", make(HostKeyPins), &diag.Diagnostics{})
+	g.Expect(err).To(MatchError(ContainSubstring("strict host key checking")))
+	g.Expect(strictPool.conns).To(BeEmpty())
+}
+
+func TestPoolClosesRefusedPooledConnections(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	pool := newPool(Config{}, startTestServer(t).dial)
+	defer func() { _ = pool.Close() }()
+
+	lease, err := pool.Acquire(ctx, "host", "user", "", nil, &diag.Diagnostics{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	client := lease.Client
+	lease.Release()
+
+	// The pooled connection was opened without pins; it must be checked (and
+	// closed) when leased for a resource that pinned a different key.
+	pins := HostKeyPins{"host": authorizedKey(generateHostKey(t))}
+
+	_, err = pool.Acquire(ctx, "host", "user", "", pins, &diag.Diagnostics{})
+	g.Expect(err).To(MatchError(ContainSubstring("has changed")))
+	g.Expect(pool.conns).To(BeEmpty())
+
+	_, _, err = client.SendRequest("keepalive@openssh.com", true, nil)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestRunCommand(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	server := startTestServer(t)
+
+	pool := newPool(Config{}, server.dial)
+	defer func() { _ = pool.Close() }()
+
+	lease, err := pool.Acquire(ctx, "host", "user", "", nil, &diag.Diagnostics{})
+	g.Expect(err).NotTo(HaveOccurred())
+	defer lease.Release()
+
+	tail := NewOutputTail(10)
+
+	err = RunCommand(ctx, lease.Client, "echo hello", nil, tail.Add)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tail.String()).To(Equal("echo hello"))
+
+	err = RunCommand(ctx, lease.Client, "fail", nil, tail.Add)
+
+	var exitErr *ssh.ExitError
+	g.Expect(errors.As(err, &exitErr)).To(BeTrue())
+	g.Expect(exitErr.ExitStatus()).To(Equal(3))
+	g.Expect(tail.String()).To(HaveSuffix("[stderr] something went wrong"))
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	g := NewWithT(t)
+	session := newTestSFTPSession(t)
+
+	target := filepath.Join(t.TempDir(), "conf", "app.conf")
+	mode := os.FileMode(0o640)
+
+	err := WriteFileAtomic(session.Client, target, strings.NewReader("first"), FileAttributes{Mode: &mode})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	err = WriteFileAtomic(session.Client, target, strings.NewReader("second"), FileAttributes{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	contents, err := os.ReadFile(target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(contents)).To(Equal("second"))
+
+	info, err := os.Stat(target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info.Mode().Perm()).To(Equal(mode))
+
+	entries, err := os.ReadDir(filepath.Dir(target))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(entries).To(HaveLen(1))
+}
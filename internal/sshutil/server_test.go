@@ -0,0 +1,171 @@
+package sshutil
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// testServer is an in-process SSH server for unit tests. It supports the
+// "sftp" subsystem (serving the local filesystem) and "exec" requests; an
+// executed command writes its command line to stdout and exits with status 0,
+// unless the command is "fail", which writes to stderr and exits with status 3.
+type testServer struct {
+	addr        string
+	connections atomic.Int32
+}
+
+func startTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	server := &testServer{addr: listener.Addr().String()}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			server.connections.Add(1)
+			go server.serve(conn, config)
+		}
+	}()
+
+	return server
+}
+
+// newTestSFTPSession starts a test server and returns an SFTP session on a
+// pooled connection to it. The session and the pool are closed when the test
+// ends.
+func newTestSFTPSession(t *testing.T) *SFTPSession {
+	t.Helper()
+
+	pool := newPool(Config{}, startTestServer(t).dial)
+	t.Cleanup(func() { _ = pool.Close() })
+
+	session, err := pool.AcquireSFTP(context.Background(), "host", "user", "", nil, &diag.Diagnostics{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+
+	return session
+}
+
+func (s *testServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		go s.serveSession(channel, requests)
+	}
+}
+
+func (s *testServer) serveSession(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer func() { _ = channel.Close() }()
+
+	for req := range requests {
+		payload := string(req.Payload[4:])
+
+		switch {
+		case req.Type == "subsystem" && payload == "sftp":
+			_ = req.Reply(true, nil)
+
+			server, err := sftp.NewServer(channel)
+			if err != nil {
+				return
+			}
+
+			_ = server.Serve()
+			return
+
+		case req.Type == "exec":
+			_ = req.Reply(true, nil)
+
+			status := uint32(0)
+			if strings.TrimSpace(payload) == "fail" {
+				_, _ = fmt.Fprintln(channel.Stderr(), "something went wrong")
+				status = 3
+			} else {
+				_, _ = fmt.Fprintln(channel, payload)
+			}
+
+			statusPayload := make([]byte, 4)
+			binary.BigEndian.PutUint32(statusPayload, status)
+			_, _ = channel.SendRequest("exit-status", false, statusPayload)
+			return
+
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+// dial connects to the test server, ignoring the private key. The host key is
+// returned as unverified, and checked against pins like in Dial.
+func (s *testServer) dial(ctx context.Context, host, user, _ string, pins HostKeyPins, _ *diag.Diagnostics) (*ssh.Client, HostKey, error) {
+	var hostKey HostKey
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return nil, hostKey, err
+	}
+
+	clientConn, chans, reqs, err := ssh.NewClientConn(conn, s.addr, &ssh.ClientConfig{
+		User: user,
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			hostKey.Key = key
+			return pins.Check(host, hostKey, false)
+		},
+	})
+	if err != nil {
+		return nil, hostKey, err
+	}
+
+	return ssh.NewClient(clientConn, chans, reqs), hostKey, nil
+}
@@ -0,0 +1,48 @@
+package sshutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// SFTPSession is an SFTP client that runs on a pooled SSH connection; closing
+// the session releases the connection back to the pool.
+type SFTPSession struct {
+	*sftp.Client
+
+	lease *Lease
+}
+
+// AcquireSFTP leases a connection from the pool (see Pool.Acquire) and starts
+// an SFTP session on it.
+func (p *Pool) AcquireSFTP(ctx context.Context, host, user, privateKey string, pins HostKeyPins, d *diag.Diagnostics) (*SFTPSession, error) {
+	lease, err := p.Acquire(ctx, host, user, privateKey, pins, d)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := sftp.NewClient(lease.Client)
+	if err != nil {
+		lease.Release()
+		return nil, fmt.Errorf("failed to create SFTP client: %w", err)
+	}
+
+	return &SFTPSession{Client: client, lease: lease}, nil
+}
+
+// SSHClient returns the pooled SSH connection that the session runs on, so
+// that commands can be run alongside the session without leasing another
+// connection.
+func (s *SFTPSession) SSHClient() *ssh.Client {
+	return s.lease.Client
+}
+
+// Close closes the SFTP session and releases the underlying connection.
+func (s *SFTPSession) Close() error {
+	defer s.lease.Release()
+	return s.Client.Close()
+}
@@ -0,0 +1,48 @@
+package sshutil
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/pkg/sftp"
+)
+
+// WriteSecretFile writes contents to a new file with a random name in dir
+// (relative to the home directory of the SSH user, if not absolute) and returns
+// its path. Other users are never able to read the file, since its mode is
+// restricted before anything is written to it; callers are responsible for
+// removing the file again.
+func WriteSecretFile(client *sftp.Client, dir, prefix string, contents []byte) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate file name: %w", err)
+	}
+
+	target := client.Join(dir, prefix+hex.EncodeToString(suffix))
+
+	file, err := client.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file %s: %w", target, err)
+	}
+
+	if err := file.Chmod(0o600); err != nil {
+		_ = file.Close()
+		_ = client.Remove(target)
+		return "", fmt.Errorf("failed to set mode of %s: %w", target, err)
+	}
+
+	if _, err := file.Write(contents); err != nil {
+		_ = file.Close()
+		_ = client.Remove(target)
+		return "", fmt.Errorf("failed to write to %s: %w", target, err)
+	}
+
+	if err := file.Close(); err != nil {
+		_ = client.Remove(target)
+		return "", fmt.Errorf("failed to write to %s: %w", target, err)
+	}
+
+	return target, nil
+}
@@ -0,0 +1,29 @@
+package sshutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWriteSecretFile(t *testing.T) {
+	g := NewWithT(t)
+	session := newTestSFTPSession(t)
+
+	dir := t.TempDir()
+
+	target, err := WriteSecretFile(session.Client, dir, ".secret-", []byte("password=secret"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(filepath.Dir(target)).To(Equal(dir))
+	g.Expect(filepath.Base(target)).To(HavePrefix(".secret-"))
+
+	contents, err := os.ReadFile(target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(contents)).To(Equal("password=secret"))
+
+	info, err := os.Stat(target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+}
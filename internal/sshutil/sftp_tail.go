@@ -0,0 +1,46 @@
+package sshutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/pkg/sftp"
+)
+
+// maxFileTailBytes limits how much of a file ReadFileTail transfers, so that
+// large (log) files do not need to be read completely.
+const maxFileTailBytes = 64 * 1024
+
+// ReadFileTail returns (at most) the last maxLines lines of a remote file.
+// Only the last maxFileTailBytes bytes of the file are considered.
+func ReadFileTail(client *sftp.Client, path string, maxLines int) (string, error) {
+	file, err := client.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	reader := bufio.NewReader(file)
+
+	if offset := info.Size() - maxFileTailBytes; offset > 0 {
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			return "", fmt.Errorf("failed to seek in %s: %w", path, err)
+		}
+
+		// Skip the (most likely) incomplete first line.
+		if _, err := reader.ReadString('\n'); err != nil {
+			return "", nil
+		}
+	}
+
+	tail := NewOutputTail(maxLines)
+	scanLines(reader, Stdout, tail.Add)
+
+	return tail.String(), nil
+}
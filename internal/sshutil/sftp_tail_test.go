@@ -0,0 +1,32 @@
+package sshutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestReadFileTail(t *testing.T) {
+	g := NewWithT(t)
+	session := newTestSFTPSession(t)
+
+	short := filepath.Join(t.TempDir(), "short.log")
+	g.Expect(os.WriteFile(short, []byte("one\ntwo\nthree\n"), 0o644)).To(Succeed())
+
+	tail, err := ReadFileTail(session.Client, short, 2)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tail).To(Equal("two\nthree"))
+
+	long := filepath.Join(t.TempDir(), "long.log")
+	g.Expect(os.WriteFile(long, []byte(strings.Repeat("filler line\n", 10000)+"last\n"), 0o644)).To(Succeed())
+
+	tail, err = ReadFileTail(session.Client, long, 2)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tail).To(Equal("filler line\nlast"))
+
+	_, err = ReadFileTail(session.Client, filepath.Join(t.TempDir(), "missing.log"), 2)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,160 @@
+package sshutil
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	pathpkg "path"
+	"strconv"
+
+	"github.com/pkg/sftp"
+)
+
+// FileAttributes are optional attributes that are applied to a file written
+// with WriteFileAtomic. Nil values are left unchanged.
+type FileAttributes struct {
+	Mode *os.FileMode
+	UID  *int
+	GID  *int
+}
+
+// ParseFileMode parses an octal file mode like "0640" or "755".
+func ParseFileMode(s string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || mode > 0o7777 {
+		return 0, fmt.Errorf("%q is not a valid octal file mode (like \"0640\")", s)
+	}
+
+	return toFileMode(uint32(mode)), nil
+}
+
+// FormatFileMode formats the permission bits of a file mode as a four-digit
+// octal string, like "0640".
+func FormatFileMode(mode os.FileMode) string {
+	bits := uint32(mode.Perm())
+	if mode&os.ModeSetuid != 0 {
+		bits |= 0o4000
+	}
+	if mode&os.ModeSetgid != 0 {
+		bits |= 0o2000
+	}
+	if mode&os.ModeSticky != 0 {
+		bits |= 0o1000
+	}
+
+	return fmt.Sprintf("%04o", bits)
+}
+
+func toFileMode(bits uint32) os.FileMode {
+	mode := os.FileMode(bits & 0o777)
+	if bits&0o4000 != 0 {
+		mode |= os.ModeSetuid
+	}
+	if bits&0o2000 != 0 {
+		mode |= os.ModeSetgid
+	}
+	if bits&0o1000 != 0 {
+		mode |= os.ModeSticky
+	}
+
+	return mode
+}
+
+// WriteFileAtomic writes the contents of r to target. The contents are first
+// written to a temporary file in the same directory, which is then renamed to
+// target; this way, readers never observe a partially written file. Missing
+// parent directories are created.
+//
+// The given attributes are applied to the temporary file before renaming it.
+// If no mode is given and target already exists, the mode of the existing file
+// is retained.
+func WriteFileAtomic(client *sftp.Client, target string, r io.Reader, attrs FileAttributes) error {
+	dir, base := pathpkg.Split(target)
+	if dir != "" {
+		if err := client.MkdirAll(dir); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Errorf("failed to generate temporary file name: %w", err)
+	}
+
+	tmp := pathpkg.Join(dir, "."+base+".tmp-"+hex.EncodeToString(suffix))
+
+	if err := writeTemporaryFile(client, tmp, target, r, attrs); err != nil {
+		_ = client.Remove(tmp)
+		return err
+	}
+
+	if err := client.PosixRename(tmp, target); err != nil {
+		_ = client.Remove(tmp)
+		return fmt.Errorf("failed to move %s to %s: %w", tmp, target, err)
+	}
+
+	return nil
+}
+
+func writeTemporaryFile(client *sftp.Client, tmp, target string, r io.Reader, attrs FileAttributes) error {
+	file, err := client.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file %s: %w", tmp, err)
+	}
+
+	if _, err := io.Copy(file, r); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write to temporary file %s: %w", tmp, err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write to temporary file %s: %w", tmp, err)
+	}
+
+	mode := attrs.Mode
+	if mode == nil {
+		if existing, err := client.Stat(target); err == nil {
+			m := existing.Mode()
+			mode = &m
+		}
+	}
+
+	if mode != nil {
+		if err := client.Chmod(tmp, *mode); err != nil {
+			return fmt.Errorf("failed to set mode of %s: %w", tmp, err)
+		}
+	}
+
+	if attrs.UID != nil || attrs.GID != nil {
+		info, err := client.Stat(tmp)
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", tmp, err)
+		}
+
+		uid, gid := FileOwner(info)
+		if attrs.UID != nil {
+			uid = *attrs.UID
+		}
+		if attrs.GID != nil {
+			gid = *attrs.GID
+		}
+
+		if err := client.Chown(tmp, uid, gid); err != nil {
+			return fmt.Errorf("failed to set owner of %s: %w", tmp, err)
+		}
+	}
+
+	return nil
+}
+
+// FileOwner returns the numeric user and group ID of a file returned by the
+// SFTP client, or -1 if they are not known.
+func FileOwner(info os.FileInfo) (int, int) {
+	if stat, ok := info.Sys().(*sftp.FileStat); ok {
+		return int(stat.UID), int(stat.GID)
+	}
+
+	return -1, -1
+}
@@ -0,0 +1,40 @@
+package sshutil
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseFileMode(t *testing.T) {
+	tests := []struct {
+		input       string
+		expected    os.FileMode
+		formatted   string
+		expectError bool
+	}{
+		{input: "0640", expected: 0o640, formatted: "0640"},
+		{input: "755", expected: 0o755, formatted: "0755"},
+		{input: "2775", expected: 0o775 | os.ModeSetgid, formatted: "2775"},
+		{input: "0888", expectError: true},
+		{input: "17777", expectError: true},
+		{input: "rw-r--r--", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mode, err := ParseFileMode(tt.input)
+			if tt.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(mode).To(Equal(tt.expected))
+			g.Expect(FormatFileMode(mode)).To(Equal(tt.formatted))
+		})
+	}
+}